@@ -0,0 +1,68 @@
+package main
+
+import (
+	"Content-Service/storage/postgres"
+	"Content-Service/storage/redis"
+	"context"
+	"flag"
+	"log"
+)
+
+func main() {
+	job := flag.String("job", "", "backfill job to run: destinations, story-content, tags, tag-alias")
+	batchSize := flag.Int("batch-size", 500, "rows per batch for story-content")
+	alias := flag.String("alias", "", "alias to add for tag-alias")
+	tag := flag.String("tag", "", "tag the alias resolves to for tag-alias")
+	flag.Parse()
+
+	db, err := postgres.ConnectDB()
+	if err != nil {
+		panic(err)
+	}
+	defer db.Close()
+
+	repo := postgres.NewContentRepository(db)
+	ctx := context.Background()
+
+	switch *job {
+	case "destinations":
+		stories, destinations, err := repo.BackfillDestinationLinks(ctx)
+		if err != nil {
+			log.Fatalf("error while linking destinations: %v", err)
+		}
+		log.Printf("linked %d stories and %d itinerary destinations", stories, destinations)
+	case "story-content":
+		if *batchSize <= 0 {
+			log.Fatalf("batch-size must be positive")
+		}
+		stories, err := repo.BackfillStoryContent(ctx, *batchSize)
+		if err != nil {
+			log.Fatalf("error while rendering story content: %v", err)
+		}
+		log.Printf("rendered the content of %d stories", stories)
+	case "tags":
+		// Rankings of related stories cached under a rewritten tag are
+		// stale; the cache TTL does not matter for dropping them.
+		related := redis.NewRelatedCache(redis.ConnectDB(), 0)
+		tags, err := repo.BackfillTags(ctx, func(tag, canonical string) {
+			stale := []string{tag}
+			if canonical != "" {
+				stale = append(stale, canonical)
+			}
+			if err := related.InvalidateTags(ctx, stale); err != nil {
+				log.Printf("error while invalidating related stories: %v", err)
+			}
+		})
+		if err != nil {
+			log.Fatalf("error while normalizing tags: %v", err)
+		}
+		log.Printf("normalized %d tags", tags)
+	case "tag-alias":
+		if err := repo.AddTagAlias(ctx, *alias, *tag); err != nil {
+			log.Fatalf("error while adding tag alias: %v", err)
+		}
+		log.Printf("added tag alias; run the tags job to rewrite existing stories")
+	default:
+		log.Fatalf("unknown backfill job %q", *job)
+	}
+}
@@ -9,8 +9,17 @@ import (
 )
 
 type Config struct {
-	Postgres PostgresConfig
-	Server   ServerConfig
+	Postgres  PostgresConfig
+	Server    ServerConfig
+	Route     RouteConfig
+	Share     ShareConfig
+	Scheduler SchedulerConfig
+	Trash     TrashConfig
+	Media     MediaConfig
+	Views     ViewsConfig
+	Trending  TrendingConfig
+	Feed      FeedConfig
+	Related   RelatedConfig
 }
 
 type PostgresConfig struct {
@@ -25,6 +34,59 @@ type ServerConfig struct {
 	USER_PORT string
 }
 
+type RouteConfig struct {
+	FLIGHT_SPEED_KMH float64
+	TRAIN_SPEED_KMH  float64
+	CAR_SPEED_KMH    float64
+}
+
+type ShareConfig struct {
+	SHARE_LINK_SECRET string
+}
+
+type SchedulerConfig struct {
+	PUBLISH_INTERVAL_SECONDS int
+	PUBLISH_BATCH_SIZE       int
+}
+
+type MediaConfig struct {
+	MEDIA_DRIVER         string
+	MEDIA_DIR            string
+	MEDIA_MAX_BYTES      int64
+	MEDIA_THUMBNAIL_SIZE int
+}
+
+type ViewsConfig struct {
+	VIEWS_FLUSH_INTERVAL_SECONDS int
+	VIEWS_FLUSH_BATCH_SIZE       int
+}
+
+type TrendingConfig struct {
+	TRENDING_GRAVITY        float64
+	TRENDING_LIKE_WEIGHT    float64
+	TRENDING_COMMENT_WEIGHT float64
+	TRENDING_VIEW_WEIGHT    float64
+	TRENDING_WINDOW_HOURS   int64
+}
+
+type FeedConfig struct {
+	FEED_FANOUT_MIN_FOLLOWERS    int64
+	FEED_TIMELINE_SIZE           int64
+	FEED_FANOUT_INTERVAL_SECONDS int
+	FEED_FANOUT_BATCH_SIZE       int
+}
+
+type RelatedConfig struct {
+	RELATED_CACHE_TTL_SECONDS int
+}
+
+type TrashConfig struct {
+	TRASH_RETENTION_DAYS     int
+	PURGE_INTERVAL_SECONDS   int
+	PURGE_BATCH_SIZE         int
+	MEDIA_ORPHAN_GRACE_HOURS int
+}
+
 func Load() *Config {
 	if err := godotenv.Load(".env"); err != nil {
 		log.Printf("error while loading .env file: %v", err)
@@ -41,6 +103,50 @@ func Load() *Config {
 		Server: ServerConfig{
 			USER_PORT: cast.ToString(coalesce("USER_PORT", ":50051")),
 		},
+		Route: RouteConfig{
+			FLIGHT_SPEED_KMH: cast.ToFloat64(coalesce("FLIGHT_SPEED_KMH", 750)),
+			TRAIN_SPEED_KMH:  cast.ToFloat64(coalesce("TRAIN_SPEED_KMH", 90)),
+			CAR_SPEED_KMH:    cast.ToFloat64(coalesce("CAR_SPEED_KMH", 70)),
+		},
+		Share: ShareConfig{
+			SHARE_LINK_SECRET: cast.ToString(coalesce("SHARE_LINK_SECRET", "")),
+		},
+		Scheduler: SchedulerConfig{
+			PUBLISH_INTERVAL_SECONDS: cast.ToInt(coalesce("PUBLISH_INTERVAL_SECONDS", 30)),
+			PUBLISH_BATCH_SIZE:       cast.ToInt(coalesce("PUBLISH_BATCH_SIZE", 100)),
+		},
+		Trash: TrashConfig{
+			TRASH_RETENTION_DAYS:     cast.ToInt(coalesce("TRASH_RETENTION_DAYS", 30)),
+			PURGE_INTERVAL_SECONDS:   cast.ToInt(coalesce("PURGE_INTERVAL_SECONDS", 3600)),
+			PURGE_BATCH_SIZE:         cast.ToInt(coalesce("PURGE_BATCH_SIZE", 100)),
+			MEDIA_ORPHAN_GRACE_HOURS: cast.ToInt(coalesce("MEDIA_ORPHAN_GRACE_HOURS", 24)),
+		},
+		Media: MediaConfig{
+			MEDIA_DRIVER:         cast.ToString(coalesce("MEDIA_DRIVER", "local")),
+			MEDIA_DIR:            cast.ToString(coalesce("MEDIA_DIR", "./media-data")),
+			MEDIA_MAX_BYTES:      cast.ToInt64(coalesce("MEDIA_MAX_BYTES", 10<<20)),
+			MEDIA_THUMBNAIL_SIZE: cast.ToInt(coalesce("MEDIA_THUMBNAIL_SIZE", 320)),
+		},
+		Views: ViewsConfig{
+			VIEWS_FLUSH_INTERVAL_SECONDS: cast.ToInt(coalesce("VIEWS_FLUSH_INTERVAL_SECONDS", 60)),
+			VIEWS_FLUSH_BATCH_SIZE:       cast.ToInt(coalesce("VIEWS_FLUSH_BATCH_SIZE", 500)),
+		},
+		Trending: TrendingConfig{
+			TRENDING_GRAVITY:        cast.ToFloat64(coalesce("TRENDING_GRAVITY", 1.8)),
+			TRENDING_LIKE_WEIGHT:    cast.ToFloat64(coalesce("TRENDING_LIKE_WEIGHT", 1)),
+			TRENDING_COMMENT_WEIGHT: cast.ToFloat64(coalesce("TRENDING_COMMENT_WEIGHT", 2)),
+			TRENDING_VIEW_WEIGHT:    cast.ToFloat64(coalesce("TRENDING_VIEW_WEIGHT", 0.1)),
+			TRENDING_WINDOW_HOURS:   cast.ToInt64(coalesce("TRENDING_WINDOW_HOURS", 7*24)),
+		},
+		Feed: FeedConfig{
+			FEED_FANOUT_MIN_FOLLOWERS:    cast.ToInt64(coalesce("FEED_FANOUT_MIN_FOLLOWERS", 0)),
+			FEED_TIMELINE_SIZE:           cast.ToInt64(coalesce("FEED_TIMELINE_SIZE", 500)),
+			FEED_FANOUT_INTERVAL_SECONDS: cast.ToInt(coalesce("FEED_FANOUT_INTERVAL_SECONDS", 5)),
+			FEED_FANOUT_BATCH_SIZE:       cast.ToInt(coalesce("FEED_FANOUT_BATCH_SIZE", 1000)),
+		},
+		Related: RelatedConfig{
+			RELATED_CACHE_TTL_SECONDS: cast.ToInt(coalesce("RELATED_CACHE_TTL_SECONDS", 3600)),
+		},
 	}
 }
 
@@ -0,0 +1,211 @@
+package export
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	FormatGPX     = "gpx"
+	FormatGeoJSON = "geojson"
+
+	KindDestination = "destination"
+	KindStory       = "story"
+)
+
+// Point is a single geotagged place written to an export.
+type Point struct {
+	ID         string
+	Kind       string
+	Name       string
+	Latitude   float64
+	Longitude  float64
+	StartDate  string
+	EndDate    string
+	CreatedAt  string
+	Activities []string
+}
+
+// Encoder writes points to an export document as they arrive, so callers can
+// stream large exports without holding every point in memory. Destination
+// points additionally form the route, which is written by End.
+type Encoder interface {
+	Begin(title string) error
+	Point(p Point) error
+	End() error
+	ContentType() string
+	Extension() string
+}
+
+// NewEncoder returns an encoder for format writing to w.
+func NewEncoder(format string, w io.Writer) (Encoder, error) {
+	switch strings.ToLower(format) {
+	case FormatGPX:
+		return &gpxEncoder{w: w}, nil
+	case FormatGeoJSON:
+		return &geoJSONEncoder{w: w}, nil
+	default:
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+type gpxEncoder struct {
+	w     io.Writer
+	title string
+	route []Point
+}
+
+func (e *gpxEncoder) ContentType() string { return "application/gpx+xml" }
+
+func (e *gpxEncoder) Extension() string { return FormatGPX }
+
+func (e *gpxEncoder) Begin(title string) error {
+	e.title = title
+	_, err := fmt.Fprintf(e.w, "%s<gpx version=\"1.1\" creator=\"Content-Service\" xmlns=\"http://www.topografix.com/GPX/1/1\">\n<metadata><name>%s</name></metadata>\n",
+		xml.Header, escapeXML(title))
+	return err
+}
+
+func (e *gpxEncoder) Point(p Point) error {
+	if p.Kind == KindDestination {
+		e.route = append(e.route, p)
+	}
+
+	_, err := fmt.Fprintf(e.w, "<wpt lat=\"%f\" lon=\"%f\"><name>%s</name><desc>%s</desc><type>%s</type></wpt>\n",
+		p.Latitude, p.Longitude, escapeXML(p.Name), escapeXML(describe(p)), p.Kind)
+	return err
+}
+
+func (e *gpxEncoder) End() error {
+	if len(e.route) > 1 {
+		if _, err := fmt.Fprintf(e.w, "<rte><name>%s</name>\n", escapeXML(e.title)); err != nil {
+			return err
+		}
+		for _, p := range e.route {
+			if _, err := fmt.Fprintf(e.w, "<rtept lat=\"%f\" lon=\"%f\"><name>%s</name></rtept>\n",
+				p.Latitude, p.Longitude, escapeXML(p.Name)); err != nil {
+				return err
+			}
+		}
+		if _, err := io.WriteString(e.w, "</rte>\n"); err != nil {
+			return err
+		}
+	}
+
+	_, err := io.WriteString(e.w, "</gpx>\n")
+	return err
+}
+
+type geoJSONEncoder struct {
+	w        io.Writer
+	title    string
+	route    [][2]float64
+	features int
+}
+
+type geoJSONFeature struct {
+	Type       string                 `json:"type"`
+	Geometry   geoJSONGeometry        `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+type geoJSONGeometry struct {
+	Type        string      `json:"type"`
+	Coordinates interface{} `json:"coordinates"`
+}
+
+func (e *geoJSONEncoder) ContentType() string { return "application/geo+json" }
+
+func (e *geoJSONEncoder) Extension() string { return FormatGeoJSON }
+
+func (e *geoJSONEncoder) Begin(title string) error {
+	e.title = title
+	_, err := io.WriteString(e.w, `{"type":"FeatureCollection","features":[`+"\n")
+	return err
+}
+
+func (e *geoJSONEncoder) Point(p Point) error {
+	if p.Kind == KindDestination {
+		e.route = append(e.route, [2]float64{p.Longitude, p.Latitude})
+	}
+
+	properties := map[string]interface{}{
+		"kind": p.Kind,
+		"name": p.Name,
+	}
+	if p.ID != "" {
+		properties["id"] = p.ID
+	}
+	if p.StartDate != "" {
+		properties["start_date"] = p.StartDate
+	}
+	if p.EndDate != "" {
+		properties["end_date"] = p.EndDate
+	}
+	if p.CreatedAt != "" {
+		properties["created_at"] = p.CreatedAt
+	}
+	if len(p.Activities) > 0 {
+		properties["activities"] = p.Activities
+	}
+
+	return e.writeFeature(geoJSONFeature{
+		Type:       "Feature",
+		Geometry:   geoJSONGeometry{Type: "Point", Coordinates: [2]float64{p.Longitude, p.Latitude}},
+		Properties: properties,
+	})
+}
+
+func (e *geoJSONEncoder) End() error {
+	if len(e.route) > 1 {
+		err := e.writeFeature(geoJSONFeature{
+			Type:       "Feature",
+			Geometry:   geoJSONGeometry{Type: "LineString", Coordinates: e.route},
+			Properties: map[string]interface{}{"kind": "route", "name": e.title},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := io.WriteString(e.w, "\n]}\n")
+	return err
+}
+
+func (e *geoJSONEncoder) writeFeature(f geoJSONFeature) error {
+	data, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+	if e.features > 0 {
+		if _, err := io.WriteString(e.w, ",\n"); err != nil {
+			return err
+		}
+	}
+	e.features++
+	_, err = e.w.Write(data)
+	return err
+}
+
+func describe(p Point) string {
+	var parts []string
+	if p.StartDate != "" || p.EndDate != "" {
+		parts = append(parts, p.StartDate+" - "+p.EndDate)
+	}
+	if p.CreatedAt != "" {
+		parts = append(parts, p.CreatedAt)
+	}
+	if len(p.Activities) > 0 {
+		parts = append(parts, strings.Join(p.Activities, "; "))
+	}
+	return strings.Join(parts, "\n")
+}
+
+func escapeXML(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -0,0 +1,101 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+)
+
+var points = []Point{
+	{Kind: KindDestination, Name: "Tashkent", Latitude: 41.2995, Longitude: 69.2401, StartDate: "2024-08-01", EndDate: "2024-08-03", Activities: []string{"Chorsu <bazaar>"}},
+	{Kind: KindDestination, Name: "Samarkand", Latitude: 39.6542, Longitude: 66.9597},
+	{ID: "s1", Kind: KindStory, Name: "Registan & me", Latitude: 39.6548, Longitude: 66.9757, CreatedAt: "2024-08-04"},
+}
+
+func encode(t *testing.T, format string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	enc, err := NewEncoder(format, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := enc.Begin("Silk Road"); err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range points {
+		if err := enc.Point(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := enc.End(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestGPXEncoder(t *testing.T) {
+	out := encode(t, FormatGPX)
+
+	dec := xml.NewDecoder(bytes.NewReader(out))
+	var waypoints, routePoints int
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("invalid GPX: %v\n%s", err, out)
+		}
+		if start, ok := tok.(xml.StartElement); ok {
+			switch start.Name.Local {
+			case "wpt":
+				waypoints++
+			case "rtept":
+				routePoints++
+			}
+		}
+	}
+
+	if waypoints != 3 || routePoints != 2 {
+		t.Errorf("got %d waypoints and %d route points, want 3 and 2", waypoints, routePoints)
+	}
+	if !strings.Contains(string(out), "Registan &amp; me") {
+		t.Errorf("story name was not escaped:\n%s", out)
+	}
+}
+
+func TestGeoJSONEncoder(t *testing.T) {
+	out := encode(t, FormatGeoJSON)
+
+	var doc struct {
+		Type     string `json:"type"`
+		Features []struct {
+			Geometry struct {
+				Type string `json:"type"`
+			} `json:"geometry"`
+			Properties map[string]interface{} `json:"properties"`
+		} `json:"features"`
+	}
+	if err := json.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("invalid GeoJSON: %v\n%s", err, out)
+	}
+
+	if doc.Type != "FeatureCollection" || len(doc.Features) != 4 {
+		t.Fatalf("got %s with %d features, want FeatureCollection with 4", doc.Type, len(doc.Features))
+	}
+	if doc.Features[3].Geometry.Type != "LineString" {
+		t.Errorf("last feature geometry = %s, want LineString", doc.Features[3].Geometry.Type)
+	}
+	if doc.Features[0].Properties["start_date"] != "2024-08-01" {
+		t.Errorf("destination properties = %v, want start_date", doc.Features[0].Properties)
+	}
+}
+
+func TestNewEncoderUnknownFormat(t *testing.T) {
+	if _, err := NewEncoder("kml", io.Discard); err == nil {
+		t.Error("expected an error for an unsupported format")
+	}
+}
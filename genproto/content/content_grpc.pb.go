@@ -19,28 +19,69 @@ import (
 const _ = grpc.SupportPackageIsVersion8
 
 const (
-	Content_CreateStories_FullMethodName       = "/content.Content/CreateStories"
-	Content_UpdateStories_FullMethodName       = "/content.Content/UpdateStories"
-	Content_DeleteStories_FullMethodName       = "/content.Content/DeleteStories"
-	Content_GetAllStories_FullMethodName       = "/content.Content/GetAllStories"
-	Content_GetStory_FullMethodName            = "/content.Content/GetStory"
-	Content_CommentStory_FullMethodName        = "/content.Content/CommentStory"
-	Content_GetCommentsOfStory_FullMethodName  = "/content.Content/GetCommentsOfStory"
-	Content_Like_FullMethodName                = "/content.Content/Like"
-	Content_Itineraries_FullMethodName         = "/content.Content/Itineraries"
-	Content_UpdateItineraries_FullMethodName   = "/content.Content/UpdateItineraries"
-	Content_DeleteItineraries_FullMethodName   = "/content.Content/DeleteItineraries"
-	Content_GetItineraries_FullMethodName      = "/content.Content/GetItineraries"
-	Content_GetItinerariesById_FullMethodName  = "/content.Content/GetItinerariesById"
-	Content_CommentItineraries_FullMethodName  = "/content.Content/CommentItineraries"
-	Content_GetDestinations_FullMethodName     = "/content.Content/GetDestinations"
-	Content_GetDestinationsById_FullMethodName = "/content.Content/GetDestinationsById"
-	Content_SendMessage_FullMethodName         = "/content.Content/SendMessage"
-	Content_GetMessages_FullMethodName         = "/content.Content/GetMessages"
-	Content_CreateTips_FullMethodName          = "/content.Content/CreateTips"
-	Content_GetTips_FullMethodName             = "/content.Content/GetTips"
-	Content_GetUserStat_FullMethodName         = "/content.Content/GetUserStat"
-	Content_TopDestinations_FullMethodName     = "/content.Content/TopDestinations"
+	Content_CreateStories_FullMethodName              = "/content.Content/CreateStories"
+	Content_UpdateStories_FullMethodName              = "/content.Content/UpdateStories"
+	Content_DeleteStories_FullMethodName              = "/content.Content/DeleteStories"
+	Content_GetAllStories_FullMethodName              = "/content.Content/GetAllStories"
+	Content_GetStory_FullMethodName                   = "/content.Content/GetStory"
+	Content_CommentStory_FullMethodName               = "/content.Content/CommentStory"
+	Content_GetCommentsOfStory_FullMethodName         = "/content.Content/GetCommentsOfStory"
+	Content_Like_FullMethodName                       = "/content.Content/Like"
+	Content_Itineraries_FullMethodName                = "/content.Content/Itineraries"
+	Content_UpdateItineraries_FullMethodName          = "/content.Content/UpdateItineraries"
+	Content_DeleteItineraries_FullMethodName          = "/content.Content/DeleteItineraries"
+	Content_GetItineraries_FullMethodName             = "/content.Content/GetItineraries"
+	Content_GetItinerariesById_FullMethodName         = "/content.Content/GetItinerariesById"
+	Content_CommentItineraries_FullMethodName         = "/content.Content/CommentItineraries"
+	Content_GetDestinations_FullMethodName            = "/content.Content/GetDestinations"
+	Content_GetDestinationsById_FullMethodName        = "/content.Content/GetDestinationsById"
+	Content_SendMessage_FullMethodName                = "/content.Content/SendMessage"
+	Content_GetMessages_FullMethodName                = "/content.Content/GetMessages"
+	Content_CreateTips_FullMethodName                 = "/content.Content/CreateTips"
+	Content_GetTips_FullMethodName                    = "/content.Content/GetTips"
+	Content_GetUserStat_FullMethodName                = "/content.Content/GetUserStat"
+	Content_TopDestinations_FullMethodName            = "/content.Content/TopDestinations"
+	Content_EstimateItineraryBudget_FullMethodName    = "/content.Content/EstimateItineraryBudget"
+	Content_GetNearby_FullMethodName                  = "/content.Content/GetNearby"
+	Content_ExportItinerary_FullMethodName            = "/content.Content/ExportItinerary"
+	Content_ExportStories_FullMethodName              = "/content.Content/ExportStories"
+	Content_CheckItinerary_FullMethodName             = "/content.Content/CheckItinerary"
+	Content_GetItinerarySchedule_FullMethodName       = "/content.Content/GetItinerarySchedule"
+	Content_CreateShareLink_FullMethodName            = "/content.Content/CreateShareLink"
+	Content_RevokeShareLink_FullMethodName            = "/content.Content/RevokeShareLink"
+	Content_InviteCollaborator_FullMethodName         = "/content.Content/InviteCollaborator"
+	Content_AcceptInvite_FullMethodName               = "/content.Content/AcceptInvite"
+	Content_RemoveCollaborator_FullMethodName         = "/content.Content/RemoveCollaborator"
+	Content_AddItineraryDestination_FullMethodName    = "/content.Content/AddItineraryDestination"
+	Content_UpdateItineraryDestination_FullMethodName = "/content.Content/UpdateItineraryDestination"
+	Content_RemoveItineraryDestination_FullMethodName = "/content.Content/RemoveItineraryDestination"
+	Content_AddItineraryActivity_FullMethodName       = "/content.Content/AddItineraryActivity"
+	Content_UpdateItineraryActivity_FullMethodName    = "/content.Content/UpdateItineraryActivity"
+	Content_RemoveItineraryActivity_FullMethodName    = "/content.Content/RemoveItineraryActivity"
+	Content_ListStoryRevisions_FullMethodName         = "/content.Content/ListStoryRevisions"
+	Content_GetStoryRevision_FullMethodName           = "/content.Content/GetStoryRevision"
+	Content_RevertStory_FullMethodName                = "/content.Content/RevertStory"
+	Content_PublishStory_FullMethodName               = "/content.Content/PublishStory"
+	Content_RestoreStory_FullMethodName               = "/content.Content/RestoreStory"
+	Content_RestoreItinerary_FullMethodName           = "/content.Content/RestoreItinerary"
+	Content_ListTrash_FullMethodName                  = "/content.Content/ListTrash"
+	Content_UploadMedia_FullMethodName                = "/content.Content/UploadMedia"
+	Content_DownloadMedia_FullMethodName              = "/content.Content/DownloadMedia"
+	Content_ListMentions_FullMethodName               = "/content.Content/ListMentions"
+	Content_ListTags_FullMethodName                   = "/content.Content/ListTags"
+	Content_GetTrendingTags_FullMethodName            = "/content.Content/GetTrendingTags"
+	Content_CreateCollection_FullMethodName           = "/content.Content/CreateCollection"
+	Content_AddToCollection_FullMethodName            = "/content.Content/AddToCollection"
+	Content_RemoveFromCollection_FullMethodName       = "/content.Content/RemoveFromCollection"
+	Content_ListCollections_FullMethodName            = "/content.Content/ListCollections"
+	Content_GetCollection_FullMethodName              = "/content.Content/GetCollection"
+	Content_CreateSeries_FullMethodName               = "/content.Content/CreateSeries"
+	Content_AddStoryToSeries_FullMethodName           = "/content.Content/AddStoryToSeries"
+	Content_ReorderSeries_FullMethodName              = "/content.Content/ReorderSeries"
+	Content_RecordView_FullMethodName                 = "/content.Content/RecordView"
+	Content_GetTrendingStories_FullMethodName         = "/content.Content/GetTrendingStories"
+	Content_GetFeed_FullMethodName                    = "/content.Content/GetFeed"
+	Content_GetRelatedStories_FullMethodName          = "/content.Content/GetRelatedStories"
 )
 
 // ContentClient is the client API for Content service.
@@ -51,15 +92,15 @@ type ContentClient interface {
 	UpdateStories(ctx context.Context, in *UpdateStoriesReq, opts ...grpc.CallOption) (*UpdateStoriesRes, error)
 	DeleteStories(ctx context.Context, in *StoryId, opts ...grpc.CallOption) (*Void, error)
 	GetAllStories(ctx context.Context, in *GetAllStoriesReq, opts ...grpc.CallOption) (*GetAllStoriesRes, error)
-	GetStory(ctx context.Context, in *StoryId, opts ...grpc.CallOption) (*GetStoryRes, error)
+	GetStory(ctx context.Context, in *GetStoryReq, opts ...grpc.CallOption) (*GetStoryRes, error)
 	CommentStory(ctx context.Context, in *CommentStoryReq, opts ...grpc.CallOption) (*CommentStoryRes, error)
 	GetCommentsOfStory(ctx context.Context, in *GetCommentsOfStoryReq, opts ...grpc.CallOption) (*GetCommentsOfStoryRes, error)
 	Like(ctx context.Context, in *LikeReq, opts ...grpc.CallOption) (*LikeRes, error)
 	Itineraries(ctx context.Context, in *ItinerariesReq, opts ...grpc.CallOption) (*ItinerariesRes, error)
 	UpdateItineraries(ctx context.Context, in *UpdateItinerariesReq, opts ...grpc.CallOption) (*ItinerariesRes, error)
-	DeleteItineraries(ctx context.Context, in *StoryId, opts ...grpc.CallOption) (*Void, error)
+	DeleteItineraries(ctx context.Context, in *DeleteItinerariesReq, opts ...grpc.CallOption) (*Void, error)
 	GetItineraries(ctx context.Context, in *GetItinerariesReq, opts ...grpc.CallOption) (*GetItinerariesRes, error)
-	GetItinerariesById(ctx context.Context, in *StoryId, opts ...grpc.CallOption) (*GetItinerariesByIdRes, error)
+	GetItinerariesById(ctx context.Context, in *GetItinerariesByIdReq, opts ...grpc.CallOption) (*GetItinerariesByIdRes, error)
 	CommentItineraries(ctx context.Context, in *CommentItinerariesReq, opts ...grpc.CallOption) (*CommentItinerariesRes, error)
 	GetDestinations(ctx context.Context, in *GetDestinationsReq, opts ...grpc.CallOption) (*GetDestinationsRes, error)
 	GetDestinationsById(ctx context.Context, in *GetDestinationsByIdReq, opts ...grpc.CallOption) (*GetDestinationsByIdRes, error)
@@ -69,6 +110,47 @@ type ContentClient interface {
 	GetTips(ctx context.Context, in *GetTipsReq, opts ...grpc.CallOption) (*GetTipsRes, error)
 	GetUserStat(ctx context.Context, in *GetUserStatReq, opts ...grpc.CallOption) (*GetUserStatRes, error)
 	TopDestinations(ctx context.Context, in *Void, opts ...grpc.CallOption) (*Answer, error)
+	EstimateItineraryBudget(ctx context.Context, in *EstimateItineraryBudgetReq, opts ...grpc.CallOption) (*EstimateItineraryBudgetRes, error)
+	GetNearby(ctx context.Context, in *GetNearbyReq, opts ...grpc.CallOption) (*GetNearbyRes, error)
+	ExportItinerary(ctx context.Context, in *ExportItineraryReq, opts ...grpc.CallOption) (Content_ExportItineraryClient, error)
+	ExportStories(ctx context.Context, in *ExportStoriesReq, opts ...grpc.CallOption) (Content_ExportStoriesClient, error)
+	CheckItinerary(ctx context.Context, in *CheckItineraryReq, opts ...grpc.CallOption) (*CheckItineraryRes, error)
+	GetItinerarySchedule(ctx context.Context, in *GetItineraryScheduleReq, opts ...grpc.CallOption) (*GetItineraryScheduleRes, error)
+	CreateShareLink(ctx context.Context, in *CreateShareLinkReq, opts ...grpc.CallOption) (*ShareLink, error)
+	RevokeShareLink(ctx context.Context, in *RevokeShareLinkReq, opts ...grpc.CallOption) (*Void, error)
+	InviteCollaborator(ctx context.Context, in *InviteCollaboratorReq, opts ...grpc.CallOption) (*Collaborator, error)
+	AcceptInvite(ctx context.Context, in *AcceptInviteReq, opts ...grpc.CallOption) (*Collaborator, error)
+	RemoveCollaborator(ctx context.Context, in *RemoveCollaboratorReq, opts ...grpc.CallOption) (*Void, error)
+	AddItineraryDestination(ctx context.Context, in *AddItineraryDestinationReq, opts ...grpc.CallOption) (*Destination, error)
+	UpdateItineraryDestination(ctx context.Context, in *UpdateItineraryDestinationReq, opts ...grpc.CallOption) (*Destination, error)
+	RemoveItineraryDestination(ctx context.Context, in *RemoveItineraryChildReq, opts ...grpc.CallOption) (*Void, error)
+	AddItineraryActivity(ctx context.Context, in *AddItineraryActivityReq, opts ...grpc.CallOption) (*Activities, error)
+	UpdateItineraryActivity(ctx context.Context, in *UpdateItineraryActivityReq, opts ...grpc.CallOption) (*Activities, error)
+	RemoveItineraryActivity(ctx context.Context, in *RemoveItineraryChildReq, opts ...grpc.CallOption) (*Void, error)
+	ListStoryRevisions(ctx context.Context, in *ListStoryRevisionsReq, opts ...grpc.CallOption) (*ListStoryRevisionsRes, error)
+	GetStoryRevision(ctx context.Context, in *GetStoryRevisionReq, opts ...grpc.CallOption) (*GetStoryRevisionRes, error)
+	RevertStory(ctx context.Context, in *RevertStoryReq, opts ...grpc.CallOption) (*UpdateStoriesRes, error)
+	PublishStory(ctx context.Context, in *PublishStoryReq, opts ...grpc.CallOption) (*PublishStoryRes, error)
+	RestoreStory(ctx context.Context, in *RestoreStoryReq, opts ...grpc.CallOption) (*GetStoryRes, error)
+	RestoreItinerary(ctx context.Context, in *RestoreItineraryReq, opts ...grpc.CallOption) (*GetItinerariesByIdRes, error)
+	ListTrash(ctx context.Context, in *ListTrashReq, opts ...grpc.CallOption) (*ListTrashRes, error)
+	UploadMedia(ctx context.Context, opts ...grpc.CallOption) (Content_UploadMediaClient, error)
+	DownloadMedia(ctx context.Context, in *DownloadMediaReq, opts ...grpc.CallOption) (Content_DownloadMediaClient, error)
+	ListMentions(ctx context.Context, in *ListMentionsReq, opts ...grpc.CallOption) (*ListMentionsRes, error)
+	ListTags(ctx context.Context, in *ListTagsReq, opts ...grpc.CallOption) (*ListTagsRes, error)
+	GetTrendingTags(ctx context.Context, in *GetTrendingTagsReq, opts ...grpc.CallOption) (*GetTrendingTagsRes, error)
+	CreateCollection(ctx context.Context, in *CreateCollectionReq, opts ...grpc.CallOption) (*Collection, error)
+	AddToCollection(ctx context.Context, in *CollectionItemReq, opts ...grpc.CallOption) (*CollectionItem, error)
+	RemoveFromCollection(ctx context.Context, in *CollectionItemReq, opts ...grpc.CallOption) (*Void, error)
+	ListCollections(ctx context.Context, in *ListCollectionsReq, opts ...grpc.CallOption) (*ListCollectionsRes, error)
+	GetCollection(ctx context.Context, in *GetCollectionReq, opts ...grpc.CallOption) (*GetCollectionRes, error)
+	CreateSeries(ctx context.Context, in *CreateSeriesReq, opts ...grpc.CallOption) (*Series, error)
+	AddStoryToSeries(ctx context.Context, in *AddStoryToSeriesReq, opts ...grpc.CallOption) (*Series, error)
+	ReorderSeries(ctx context.Context, in *ReorderSeriesReq, opts ...grpc.CallOption) (*Series, error)
+	RecordView(ctx context.Context, in *RecordViewReq, opts ...grpc.CallOption) (*Void, error)
+	GetTrendingStories(ctx context.Context, in *GetTrendingStoriesReq, opts ...grpc.CallOption) (*GetTrendingStoriesRes, error)
+	GetFeed(ctx context.Context, in *GetFeedReq, opts ...grpc.CallOption) (*GetFeedRes, error)
+	GetRelatedStories(ctx context.Context, in *GetRelatedStoriesReq, opts ...grpc.CallOption) (*GetRelatedStoriesRes, error)
 }
 
 type contentClient struct {
@@ -119,7 +201,7 @@ func (c *contentClient) GetAllStories(ctx context.Context, in *GetAllStoriesReq,
 	return out, nil
 }
 
-func (c *contentClient) GetStory(ctx context.Context, in *StoryId, opts ...grpc.CallOption) (*GetStoryRes, error) {
+func (c *contentClient) GetStory(ctx context.Context, in *GetStoryReq, opts ...grpc.CallOption) (*GetStoryRes, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetStoryRes)
 	err := c.cc.Invoke(ctx, Content_GetStory_FullMethodName, in, out, cOpts...)
@@ -179,7 +261,7 @@ func (c *contentClient) UpdateItineraries(ctx context.Context, in *UpdateItinera
 	return out, nil
 }
 
-func (c *contentClient) DeleteItineraries(ctx context.Context, in *StoryId, opts ...grpc.CallOption) (*Void, error) {
+func (c *contentClient) DeleteItineraries(ctx context.Context, in *DeleteItinerariesReq, opts ...grpc.CallOption) (*Void, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Void)
 	err := c.cc.Invoke(ctx, Content_DeleteItineraries_FullMethodName, in, out, cOpts...)
@@ -199,7 +281,7 @@ func (c *contentClient) GetItineraries(ctx context.Context, in *GetItinerariesRe
 	return out, nil
 }
 
-func (c *contentClient) GetItinerariesById(ctx context.Context, in *StoryId, opts ...grpc.CallOption) (*GetItinerariesByIdRes, error) {
+func (c *contentClient) GetItinerariesById(ctx context.Context, in *GetItinerariesByIdReq, opts ...grpc.CallOption) (*GetItinerariesByIdRes, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetItinerariesByIdRes)
 	err := c.cc.Invoke(ctx, Content_GetItinerariesById_FullMethodName, in, out, cOpts...)
@@ -299,6 +381,510 @@ func (c *contentClient) TopDestinations(ctx context.Context, in *Void, opts ...g
 	return out, nil
 }
 
+func (c *contentClient) EstimateItineraryBudget(ctx context.Context, in *EstimateItineraryBudgetReq, opts ...grpc.CallOption) (*EstimateItineraryBudgetRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EstimateItineraryBudgetRes)
+	err := c.cc.Invoke(ctx, Content_EstimateItineraryBudget_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contentClient) GetNearby(ctx context.Context, in *GetNearbyReq, opts ...grpc.CallOption) (*GetNearbyRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetNearbyRes)
+	err := c.cc.Invoke(ctx, Content_GetNearby_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contentClient) ExportItinerary(ctx context.Context, in *ExportItineraryReq, opts ...grpc.CallOption) (Content_ExportItineraryClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Content_ServiceDesc.Streams[0], Content_ExportItinerary_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &contentExportItineraryClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Content_ExportItineraryClient interface {
+	Recv() (*ExportChunk, error)
+	grpc.ClientStream
+}
+
+type contentExportItineraryClient struct {
+	grpc.ClientStream
+}
+
+func (x *contentExportItineraryClient) Recv() (*ExportChunk, error) {
+	m := new(ExportChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *contentClient) ExportStories(ctx context.Context, in *ExportStoriesReq, opts ...grpc.CallOption) (Content_ExportStoriesClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Content_ServiceDesc.Streams[1], Content_ExportStories_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &contentExportStoriesClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Content_ExportStoriesClient interface {
+	Recv() (*ExportChunk, error)
+	grpc.ClientStream
+}
+
+type contentExportStoriesClient struct {
+	grpc.ClientStream
+}
+
+func (x *contentExportStoriesClient) Recv() (*ExportChunk, error) {
+	m := new(ExportChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *contentClient) CheckItinerary(ctx context.Context, in *CheckItineraryReq, opts ...grpc.CallOption) (*CheckItineraryRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckItineraryRes)
+	err := c.cc.Invoke(ctx, Content_CheckItinerary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contentClient) GetItinerarySchedule(ctx context.Context, in *GetItineraryScheduleReq, opts ...grpc.CallOption) (*GetItineraryScheduleRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetItineraryScheduleRes)
+	err := c.cc.Invoke(ctx, Content_GetItinerarySchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contentClient) CreateShareLink(ctx context.Context, in *CreateShareLinkReq, opts ...grpc.CallOption) (*ShareLink, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShareLink)
+	err := c.cc.Invoke(ctx, Content_CreateShareLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contentClient) RevokeShareLink(ctx context.Context, in *RevokeShareLinkReq, opts ...grpc.CallOption) (*Void, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Void)
+	err := c.cc.Invoke(ctx, Content_RevokeShareLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contentClient) InviteCollaborator(ctx context.Context, in *InviteCollaboratorReq, opts ...grpc.CallOption) (*Collaborator, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Collaborator)
+	err := c.cc.Invoke(ctx, Content_InviteCollaborator_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contentClient) AcceptInvite(ctx context.Context, in *AcceptInviteReq, opts ...grpc.CallOption) (*Collaborator, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Collaborator)
+	err := c.cc.Invoke(ctx, Content_AcceptInvite_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contentClient) RemoveCollaborator(ctx context.Context, in *RemoveCollaboratorReq, opts ...grpc.CallOption) (*Void, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Void)
+	err := c.cc.Invoke(ctx, Content_RemoveCollaborator_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contentClient) AddItineraryDestination(ctx context.Context, in *AddItineraryDestinationReq, opts ...grpc.CallOption) (*Destination, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Destination)
+	err := c.cc.Invoke(ctx, Content_AddItineraryDestination_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contentClient) UpdateItineraryDestination(ctx context.Context, in *UpdateItineraryDestinationReq, opts ...grpc.CallOption) (*Destination, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Destination)
+	err := c.cc.Invoke(ctx, Content_UpdateItineraryDestination_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contentClient) RemoveItineraryDestination(ctx context.Context, in *RemoveItineraryChildReq, opts ...grpc.CallOption) (*Void, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Void)
+	err := c.cc.Invoke(ctx, Content_RemoveItineraryDestination_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contentClient) AddItineraryActivity(ctx context.Context, in *AddItineraryActivityReq, opts ...grpc.CallOption) (*Activities, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Activities)
+	err := c.cc.Invoke(ctx, Content_AddItineraryActivity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contentClient) UpdateItineraryActivity(ctx context.Context, in *UpdateItineraryActivityReq, opts ...grpc.CallOption) (*Activities, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Activities)
+	err := c.cc.Invoke(ctx, Content_UpdateItineraryActivity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contentClient) RemoveItineraryActivity(ctx context.Context, in *RemoveItineraryChildReq, opts ...grpc.CallOption) (*Void, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Void)
+	err := c.cc.Invoke(ctx, Content_RemoveItineraryActivity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contentClient) ListStoryRevisions(ctx context.Context, in *ListStoryRevisionsReq, opts ...grpc.CallOption) (*ListStoryRevisionsRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListStoryRevisionsRes)
+	err := c.cc.Invoke(ctx, Content_ListStoryRevisions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contentClient) GetStoryRevision(ctx context.Context, in *GetStoryRevisionReq, opts ...grpc.CallOption) (*GetStoryRevisionRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetStoryRevisionRes)
+	err := c.cc.Invoke(ctx, Content_GetStoryRevision_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contentClient) RevertStory(ctx context.Context, in *RevertStoryReq, opts ...grpc.CallOption) (*UpdateStoriesRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateStoriesRes)
+	err := c.cc.Invoke(ctx, Content_RevertStory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contentClient) PublishStory(ctx context.Context, in *PublishStoryReq, opts ...grpc.CallOption) (*PublishStoryRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PublishStoryRes)
+	err := c.cc.Invoke(ctx, Content_PublishStory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contentClient) RestoreStory(ctx context.Context, in *RestoreStoryReq, opts ...grpc.CallOption) (*GetStoryRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetStoryRes)
+	err := c.cc.Invoke(ctx, Content_RestoreStory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contentClient) RestoreItinerary(ctx context.Context, in *RestoreItineraryReq, opts ...grpc.CallOption) (*GetItinerariesByIdRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetItinerariesByIdRes)
+	err := c.cc.Invoke(ctx, Content_RestoreItinerary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contentClient) ListTrash(ctx context.Context, in *ListTrashReq, opts ...grpc.CallOption) (*ListTrashRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTrashRes)
+	err := c.cc.Invoke(ctx, Content_ListTrash_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contentClient) UploadMedia(ctx context.Context, opts ...grpc.CallOption) (Content_UploadMediaClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Content_ServiceDesc.Streams[2], Content_UploadMedia_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &contentUploadMediaClient{ClientStream: stream}
+	return x, nil
+}
+
+type Content_UploadMediaClient interface {
+	Send(*UploadMediaReq) error
+	CloseAndRecv() (*Media, error)
+	grpc.ClientStream
+}
+
+type contentUploadMediaClient struct {
+	grpc.ClientStream
+}
+
+func (x *contentUploadMediaClient) Send(m *UploadMediaReq) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *contentUploadMediaClient) CloseAndRecv() (*Media, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(Media)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *contentClient) DownloadMedia(ctx context.Context, in *DownloadMediaReq, opts ...grpc.CallOption) (Content_DownloadMediaClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Content_ServiceDesc.Streams[3], Content_DownloadMedia_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &contentDownloadMediaClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Content_DownloadMediaClient interface {
+	Recv() (*ExportChunk, error)
+	grpc.ClientStream
+}
+
+type contentDownloadMediaClient struct {
+	grpc.ClientStream
+}
+
+func (x *contentDownloadMediaClient) Recv() (*ExportChunk, error) {
+	m := new(ExportChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *contentClient) ListMentions(ctx context.Context, in *ListMentionsReq, opts ...grpc.CallOption) (*ListMentionsRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMentionsRes)
+	err := c.cc.Invoke(ctx, Content_ListMentions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contentClient) ListTags(ctx context.Context, in *ListTagsReq, opts ...grpc.CallOption) (*ListTagsRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTagsRes)
+	err := c.cc.Invoke(ctx, Content_ListTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contentClient) GetTrendingTags(ctx context.Context, in *GetTrendingTagsReq, opts ...grpc.CallOption) (*GetTrendingTagsRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTrendingTagsRes)
+	err := c.cc.Invoke(ctx, Content_GetTrendingTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contentClient) CreateCollection(ctx context.Context, in *CreateCollectionReq, opts ...grpc.CallOption) (*Collection, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Collection)
+	err := c.cc.Invoke(ctx, Content_CreateCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contentClient) AddToCollection(ctx context.Context, in *CollectionItemReq, opts ...grpc.CallOption) (*CollectionItem, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CollectionItem)
+	err := c.cc.Invoke(ctx, Content_AddToCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contentClient) RemoveFromCollection(ctx context.Context, in *CollectionItemReq, opts ...grpc.CallOption) (*Void, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Void)
+	err := c.cc.Invoke(ctx, Content_RemoveFromCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contentClient) ListCollections(ctx context.Context, in *ListCollectionsReq, opts ...grpc.CallOption) (*ListCollectionsRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCollectionsRes)
+	err := c.cc.Invoke(ctx, Content_ListCollections_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contentClient) GetCollection(ctx context.Context, in *GetCollectionReq, opts ...grpc.CallOption) (*GetCollectionRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCollectionRes)
+	err := c.cc.Invoke(ctx, Content_GetCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contentClient) CreateSeries(ctx context.Context, in *CreateSeriesReq, opts ...grpc.CallOption) (*Series, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Series)
+	err := c.cc.Invoke(ctx, Content_CreateSeries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contentClient) AddStoryToSeries(ctx context.Context, in *AddStoryToSeriesReq, opts ...grpc.CallOption) (*Series, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Series)
+	err := c.cc.Invoke(ctx, Content_AddStoryToSeries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contentClient) ReorderSeries(ctx context.Context, in *ReorderSeriesReq, opts ...grpc.CallOption) (*Series, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Series)
+	err := c.cc.Invoke(ctx, Content_ReorderSeries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contentClient) RecordView(ctx context.Context, in *RecordViewReq, opts ...grpc.CallOption) (*Void, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Void)
+	err := c.cc.Invoke(ctx, Content_RecordView_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contentClient) GetTrendingStories(ctx context.Context, in *GetTrendingStoriesReq, opts ...grpc.CallOption) (*GetTrendingStoriesRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTrendingStoriesRes)
+	err := c.cc.Invoke(ctx, Content_GetTrendingStories_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contentClient) GetFeed(ctx context.Context, in *GetFeedReq, opts ...grpc.CallOption) (*GetFeedRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetFeedRes)
+	err := c.cc.Invoke(ctx, Content_GetFeed_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contentClient) GetRelatedStories(ctx context.Context, in *GetRelatedStoriesReq, opts ...grpc.CallOption) (*GetRelatedStoriesRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRelatedStoriesRes)
+	err := c.cc.Invoke(ctx, Content_GetRelatedStories_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ContentServer is the server API for Content service.
 // All implementations must embed UnimplementedContentServer
 // for forward compatibility
@@ -307,15 +893,15 @@ type ContentServer interface {
 	UpdateStories(context.Context, *UpdateStoriesReq) (*UpdateStoriesRes, error)
 	DeleteStories(context.Context, *StoryId) (*Void, error)
 	GetAllStories(context.Context, *GetAllStoriesReq) (*GetAllStoriesRes, error)
-	GetStory(context.Context, *StoryId) (*GetStoryRes, error)
+	GetStory(context.Context, *GetStoryReq) (*GetStoryRes, error)
 	CommentStory(context.Context, *CommentStoryReq) (*CommentStoryRes, error)
 	GetCommentsOfStory(context.Context, *GetCommentsOfStoryReq) (*GetCommentsOfStoryRes, error)
 	Like(context.Context, *LikeReq) (*LikeRes, error)
 	Itineraries(context.Context, *ItinerariesReq) (*ItinerariesRes, error)
 	UpdateItineraries(context.Context, *UpdateItinerariesReq) (*ItinerariesRes, error)
-	DeleteItineraries(context.Context, *StoryId) (*Void, error)
+	DeleteItineraries(context.Context, *DeleteItinerariesReq) (*Void, error)
 	GetItineraries(context.Context, *GetItinerariesReq) (*GetItinerariesRes, error)
-	GetItinerariesById(context.Context, *StoryId) (*GetItinerariesByIdRes, error)
+	GetItinerariesById(context.Context, *GetItinerariesByIdReq) (*GetItinerariesByIdRes, error)
 	CommentItineraries(context.Context, *CommentItinerariesReq) (*CommentItinerariesRes, error)
 	GetDestinations(context.Context, *GetDestinationsReq) (*GetDestinationsRes, error)
 	GetDestinationsById(context.Context, *GetDestinationsByIdReq) (*GetDestinationsByIdRes, error)
@@ -325,484 +911,1403 @@ type ContentServer interface {
 	GetTips(context.Context, *GetTipsReq) (*GetTipsRes, error)
 	GetUserStat(context.Context, *GetUserStatReq) (*GetUserStatRes, error)
 	TopDestinations(context.Context, *Void) (*Answer, error)
+	EstimateItineraryBudget(context.Context, *EstimateItineraryBudgetReq) (*EstimateItineraryBudgetRes, error)
+	GetNearby(context.Context, *GetNearbyReq) (*GetNearbyRes, error)
+	ExportItinerary(*ExportItineraryReq, Content_ExportItineraryServer) error
+	ExportStories(*ExportStoriesReq, Content_ExportStoriesServer) error
+	CheckItinerary(context.Context, *CheckItineraryReq) (*CheckItineraryRes, error)
+	GetItinerarySchedule(context.Context, *GetItineraryScheduleReq) (*GetItineraryScheduleRes, error)
+	CreateShareLink(context.Context, *CreateShareLinkReq) (*ShareLink, error)
+	RevokeShareLink(context.Context, *RevokeShareLinkReq) (*Void, error)
+	InviteCollaborator(context.Context, *InviteCollaboratorReq) (*Collaborator, error)
+	AcceptInvite(context.Context, *AcceptInviteReq) (*Collaborator, error)
+	RemoveCollaborator(context.Context, *RemoveCollaboratorReq) (*Void, error)
+	AddItineraryDestination(context.Context, *AddItineraryDestinationReq) (*Destination, error)
+	UpdateItineraryDestination(context.Context, *UpdateItineraryDestinationReq) (*Destination, error)
+	RemoveItineraryDestination(context.Context, *RemoveItineraryChildReq) (*Void, error)
+	AddItineraryActivity(context.Context, *AddItineraryActivityReq) (*Activities, error)
+	UpdateItineraryActivity(context.Context, *UpdateItineraryActivityReq) (*Activities, error)
+	RemoveItineraryActivity(context.Context, *RemoveItineraryChildReq) (*Void, error)
+	ListStoryRevisions(context.Context, *ListStoryRevisionsReq) (*ListStoryRevisionsRes, error)
+	GetStoryRevision(context.Context, *GetStoryRevisionReq) (*GetStoryRevisionRes, error)
+	RevertStory(context.Context, *RevertStoryReq) (*UpdateStoriesRes, error)
+	PublishStory(context.Context, *PublishStoryReq) (*PublishStoryRes, error)
+	RestoreStory(context.Context, *RestoreStoryReq) (*GetStoryRes, error)
+	RestoreItinerary(context.Context, *RestoreItineraryReq) (*GetItinerariesByIdRes, error)
+	ListTrash(context.Context, *ListTrashReq) (*ListTrashRes, error)
+	UploadMedia(Content_UploadMediaServer) error
+	DownloadMedia(*DownloadMediaReq, Content_DownloadMediaServer) error
+	ListMentions(context.Context, *ListMentionsReq) (*ListMentionsRes, error)
+	ListTags(context.Context, *ListTagsReq) (*ListTagsRes, error)
+	GetTrendingTags(context.Context, *GetTrendingTagsReq) (*GetTrendingTagsRes, error)
+	CreateCollection(context.Context, *CreateCollectionReq) (*Collection, error)
+	AddToCollection(context.Context, *CollectionItemReq) (*CollectionItem, error)
+	RemoveFromCollection(context.Context, *CollectionItemReq) (*Void, error)
+	ListCollections(context.Context, *ListCollectionsReq) (*ListCollectionsRes, error)
+	GetCollection(context.Context, *GetCollectionReq) (*GetCollectionRes, error)
+	CreateSeries(context.Context, *CreateSeriesReq) (*Series, error)
+	AddStoryToSeries(context.Context, *AddStoryToSeriesReq) (*Series, error)
+	ReorderSeries(context.Context, *ReorderSeriesReq) (*Series, error)
+	RecordView(context.Context, *RecordViewReq) (*Void, error)
+	GetTrendingStories(context.Context, *GetTrendingStoriesReq) (*GetTrendingStoriesRes, error)
+	GetFeed(context.Context, *GetFeedReq) (*GetFeedRes, error)
+	GetRelatedStories(context.Context, *GetRelatedStoriesReq) (*GetRelatedStoriesRes, error)
+	mustEmbedUnimplementedContentServer()
+}
+
+// UnimplementedContentServer must be embedded to have forward compatible implementations.
+type UnimplementedContentServer struct {
+}
+
+func (UnimplementedContentServer) CreateStories(context.Context, *CreateStoriesRequest) (*CreateStoriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateStories not implemented")
+}
+func (UnimplementedContentServer) UpdateStories(context.Context, *UpdateStoriesReq) (*UpdateStoriesRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateStories not implemented")
+}
+func (UnimplementedContentServer) DeleteStories(context.Context, *StoryId) (*Void, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteStories not implemented")
+}
+func (UnimplementedContentServer) GetAllStories(context.Context, *GetAllStoriesReq) (*GetAllStoriesRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAllStories not implemented")
+}
+func (UnimplementedContentServer) GetStory(context.Context, *GetStoryReq) (*GetStoryRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStory not implemented")
+}
+func (UnimplementedContentServer) CommentStory(context.Context, *CommentStoryReq) (*CommentStoryRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CommentStory not implemented")
+}
+func (UnimplementedContentServer) GetCommentsOfStory(context.Context, *GetCommentsOfStoryReq) (*GetCommentsOfStoryRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCommentsOfStory not implemented")
+}
+func (UnimplementedContentServer) Like(context.Context, *LikeReq) (*LikeRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Like not implemented")
+}
+func (UnimplementedContentServer) Itineraries(context.Context, *ItinerariesReq) (*ItinerariesRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Itineraries not implemented")
+}
+func (UnimplementedContentServer) UpdateItineraries(context.Context, *UpdateItinerariesReq) (*ItinerariesRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateItineraries not implemented")
+}
+func (UnimplementedContentServer) DeleteItineraries(context.Context, *DeleteItinerariesReq) (*Void, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteItineraries not implemented")
+}
+func (UnimplementedContentServer) GetItineraries(context.Context, *GetItinerariesReq) (*GetItinerariesRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetItineraries not implemented")
+}
+func (UnimplementedContentServer) GetItinerariesById(context.Context, *GetItinerariesByIdReq) (*GetItinerariesByIdRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetItinerariesById not implemented")
+}
+func (UnimplementedContentServer) CommentItineraries(context.Context, *CommentItinerariesReq) (*CommentItinerariesRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CommentItineraries not implemented")
+}
+func (UnimplementedContentServer) GetDestinations(context.Context, *GetDestinationsReq) (*GetDestinationsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDestinations not implemented")
+}
+func (UnimplementedContentServer) GetDestinationsById(context.Context, *GetDestinationsByIdReq) (*GetDestinationsByIdRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDestinationsById not implemented")
+}
+func (UnimplementedContentServer) SendMessage(context.Context, *SendMessageReq) (*SendMessageRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendMessage not implemented")
+}
+func (UnimplementedContentServer) GetMessages(context.Context, *GetMessagesReq) (*GetMessagesRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMessages not implemented")
+}
+func (UnimplementedContentServer) CreateTips(context.Context, *CreateTipsReq) (*CreateTipsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTips not implemented")
+}
+func (UnimplementedContentServer) GetTips(context.Context, *GetTipsReq) (*GetTipsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTips not implemented")
+}
+func (UnimplementedContentServer) GetUserStat(context.Context, *GetUserStatReq) (*GetUserStatRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserStat not implemented")
+}
+func (UnimplementedContentServer) TopDestinations(context.Context, *Void) (*Answer, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TopDestinations not implemented")
+}
+func (UnimplementedContentServer) EstimateItineraryBudget(context.Context, *EstimateItineraryBudgetReq) (*EstimateItineraryBudgetRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EstimateItineraryBudget not implemented")
+}
+func (UnimplementedContentServer) GetNearby(context.Context, *GetNearbyReq) (*GetNearbyRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNearby not implemented")
+}
+func (UnimplementedContentServer) ExportItinerary(*ExportItineraryReq, Content_ExportItineraryServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportItinerary not implemented")
+}
+func (UnimplementedContentServer) ExportStories(*ExportStoriesReq, Content_ExportStoriesServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportStories not implemented")
+}
+func (UnimplementedContentServer) CheckItinerary(context.Context, *CheckItineraryReq) (*CheckItineraryRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckItinerary not implemented")
+}
+func (UnimplementedContentServer) GetItinerarySchedule(context.Context, *GetItineraryScheduleReq) (*GetItineraryScheduleRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetItinerarySchedule not implemented")
+}
+func (UnimplementedContentServer) CreateShareLink(context.Context, *CreateShareLinkReq) (*ShareLink, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateShareLink not implemented")
+}
+func (UnimplementedContentServer) RevokeShareLink(context.Context, *RevokeShareLinkReq) (*Void, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeShareLink not implemented")
+}
+func (UnimplementedContentServer) InviteCollaborator(context.Context, *InviteCollaboratorReq) (*Collaborator, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InviteCollaborator not implemented")
+}
+func (UnimplementedContentServer) AcceptInvite(context.Context, *AcceptInviteReq) (*Collaborator, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptInvite not implemented")
+}
+func (UnimplementedContentServer) RemoveCollaborator(context.Context, *RemoveCollaboratorReq) (*Void, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveCollaborator not implemented")
+}
+func (UnimplementedContentServer) AddItineraryDestination(context.Context, *AddItineraryDestinationReq) (*Destination, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddItineraryDestination not implemented")
+}
+func (UnimplementedContentServer) UpdateItineraryDestination(context.Context, *UpdateItineraryDestinationReq) (*Destination, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateItineraryDestination not implemented")
+}
+func (UnimplementedContentServer) RemoveItineraryDestination(context.Context, *RemoveItineraryChildReq) (*Void, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveItineraryDestination not implemented")
+}
+func (UnimplementedContentServer) AddItineraryActivity(context.Context, *AddItineraryActivityReq) (*Activities, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddItineraryActivity not implemented")
+}
+func (UnimplementedContentServer) UpdateItineraryActivity(context.Context, *UpdateItineraryActivityReq) (*Activities, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateItineraryActivity not implemented")
+}
+func (UnimplementedContentServer) RemoveItineraryActivity(context.Context, *RemoveItineraryChildReq) (*Void, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveItineraryActivity not implemented")
+}
+func (UnimplementedContentServer) ListStoryRevisions(context.Context, *ListStoryRevisionsReq) (*ListStoryRevisionsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListStoryRevisions not implemented")
+}
+func (UnimplementedContentServer) GetStoryRevision(context.Context, *GetStoryRevisionReq) (*GetStoryRevisionRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStoryRevision not implemented")
+}
+func (UnimplementedContentServer) RevertStory(context.Context, *RevertStoryReq) (*UpdateStoriesRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevertStory not implemented")
+}
+func (UnimplementedContentServer) PublishStory(context.Context, *PublishStoryReq) (*PublishStoryRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PublishStory not implemented")
+}
+func (UnimplementedContentServer) RestoreStory(context.Context, *RestoreStoryReq) (*GetStoryRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreStory not implemented")
+}
+func (UnimplementedContentServer) RestoreItinerary(context.Context, *RestoreItineraryReq) (*GetItinerariesByIdRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreItinerary not implemented")
+}
+func (UnimplementedContentServer) ListTrash(context.Context, *ListTrashReq) (*ListTrashRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTrash not implemented")
+}
+func (UnimplementedContentServer) UploadMedia(Content_UploadMediaServer) error {
+	return status.Errorf(codes.Unimplemented, "method UploadMedia not implemented")
+}
+func (UnimplementedContentServer) DownloadMedia(*DownloadMediaReq, Content_DownloadMediaServer) error {
+	return status.Errorf(codes.Unimplemented, "method DownloadMedia not implemented")
+}
+func (UnimplementedContentServer) ListMentions(context.Context, *ListMentionsReq) (*ListMentionsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMentions not implemented")
+}
+func (UnimplementedContentServer) ListTags(context.Context, *ListTagsReq) (*ListTagsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTags not implemented")
+}
+func (UnimplementedContentServer) GetTrendingTags(context.Context, *GetTrendingTagsReq) (*GetTrendingTagsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTrendingTags not implemented")
+}
+func (UnimplementedContentServer) CreateCollection(context.Context, *CreateCollectionReq) (*Collection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCollection not implemented")
+}
+func (UnimplementedContentServer) AddToCollection(context.Context, *CollectionItemReq) (*CollectionItem, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddToCollection not implemented")
+}
+func (UnimplementedContentServer) RemoveFromCollection(context.Context, *CollectionItemReq) (*Void, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveFromCollection not implemented")
+}
+func (UnimplementedContentServer) ListCollections(context.Context, *ListCollectionsReq) (*ListCollectionsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCollections not implemented")
+}
+func (UnimplementedContentServer) GetCollection(context.Context, *GetCollectionReq) (*GetCollectionRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCollection not implemented")
+}
+func (UnimplementedContentServer) CreateSeries(context.Context, *CreateSeriesReq) (*Series, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSeries not implemented")
+}
+func (UnimplementedContentServer) AddStoryToSeries(context.Context, *AddStoryToSeriesReq) (*Series, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddStoryToSeries not implemented")
+}
+func (UnimplementedContentServer) ReorderSeries(context.Context, *ReorderSeriesReq) (*Series, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReorderSeries not implemented")
+}
+func (UnimplementedContentServer) RecordView(context.Context, *RecordViewReq) (*Void, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordView not implemented")
+}
+func (UnimplementedContentServer) GetTrendingStories(context.Context, *GetTrendingStoriesReq) (*GetTrendingStoriesRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTrendingStories not implemented")
+}
+func (UnimplementedContentServer) GetFeed(context.Context, *GetFeedReq) (*GetFeedRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFeed not implemented")
+}
+func (UnimplementedContentServer) GetRelatedStories(context.Context, *GetRelatedStoriesReq) (*GetRelatedStoriesRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRelatedStories not implemented")
+}
+func (UnimplementedContentServer) mustEmbedUnimplementedContentServer() {}
+
+// UnsafeContentServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ContentServer will
+// result in compilation errors.
+type UnsafeContentServer interface {
 	mustEmbedUnimplementedContentServer()
 }
 
-// UnimplementedContentServer must be embedded to have forward compatible implementations.
-type UnimplementedContentServer struct {
+func RegisterContentServer(s grpc.ServiceRegistrar, srv ContentServer) {
+	s.RegisterService(&Content_ServiceDesc, srv)
+}
+
+func _Content_CreateStories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateStoriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentServer).CreateStories(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Content_CreateStories_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentServer).CreateStories(ctx, req.(*CreateStoriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Content_UpdateStories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateStoriesReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentServer).UpdateStories(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Content_UpdateStories_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentServer).UpdateStories(ctx, req.(*UpdateStoriesReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Content_DeleteStories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StoryId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentServer).DeleteStories(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Content_DeleteStories_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentServer).DeleteStories(ctx, req.(*StoryId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Content_GetAllStories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAllStoriesReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentServer).GetAllStories(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Content_GetAllStories_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentServer).GetAllStories(ctx, req.(*GetAllStoriesReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Content_GetStory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStoryReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentServer).GetStory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Content_GetStory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentServer).GetStory(ctx, req.(*GetStoryReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Content_CommentStory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CommentStoryReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentServer).CommentStory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Content_CommentStory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentServer).CommentStory(ctx, req.(*CommentStoryReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Content_GetCommentsOfStory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCommentsOfStoryReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentServer).GetCommentsOfStory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Content_GetCommentsOfStory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentServer).GetCommentsOfStory(ctx, req.(*GetCommentsOfStoryReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Content_Like_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LikeReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentServer).Like(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Content_Like_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentServer).Like(ctx, req.(*LikeReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Content_Itineraries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ItinerariesReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentServer).Itineraries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Content_Itineraries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentServer).Itineraries(ctx, req.(*ItinerariesReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Content_UpdateItineraries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateItinerariesReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentServer).UpdateItineraries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Content_UpdateItineraries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentServer).UpdateItineraries(ctx, req.(*UpdateItinerariesReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Content_DeleteItineraries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteItinerariesReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentServer).DeleteItineraries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Content_DeleteItineraries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentServer).DeleteItineraries(ctx, req.(*DeleteItinerariesReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Content_GetItineraries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetItinerariesReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentServer).GetItineraries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Content_GetItineraries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentServer).GetItineraries(ctx, req.(*GetItinerariesReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Content_GetItinerariesById_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetItinerariesByIdReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentServer).GetItinerariesById(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Content_GetItinerariesById_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentServer).GetItinerariesById(ctx, req.(*GetItinerariesByIdReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Content_CommentItineraries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CommentItinerariesReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentServer).CommentItineraries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Content_CommentItineraries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentServer).CommentItineraries(ctx, req.(*CommentItinerariesReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Content_GetDestinations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDestinationsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentServer).GetDestinations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Content_GetDestinations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentServer).GetDestinations(ctx, req.(*GetDestinationsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Content_GetDestinationsById_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDestinationsByIdReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentServer).GetDestinationsById(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Content_GetDestinationsById_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentServer).GetDestinationsById(ctx, req.(*GetDestinationsByIdReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Content_SendMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendMessageReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentServer).SendMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Content_SendMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentServer).SendMessage(ctx, req.(*SendMessageReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Content_GetMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMessagesReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentServer).GetMessages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Content_GetMessages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentServer).GetMessages(ctx, req.(*GetMessagesReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Content_CreateTips_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTipsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentServer).CreateTips(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Content_CreateTips_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentServer).CreateTips(ctx, req.(*CreateTipsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Content_GetTips_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTipsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentServer).GetTips(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Content_GetTips_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentServer).GetTips(ctx, req.(*GetTipsReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (UnimplementedContentServer) CreateStories(context.Context, *CreateStoriesRequest) (*CreateStoriesResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateStories not implemented")
+func _Content_GetUserStat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserStatReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentServer).GetUserStat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Content_GetUserStat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentServer).GetUserStat(ctx, req.(*GetUserStatReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedContentServer) UpdateStories(context.Context, *UpdateStoriesReq) (*UpdateStoriesRes, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateStories not implemented")
+
+func _Content_TopDestinations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Void)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentServer).TopDestinations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Content_TopDestinations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentServer).TopDestinations(ctx, req.(*Void))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedContentServer) DeleteStories(context.Context, *StoryId) (*Void, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteStories not implemented")
+
+func _Content_EstimateItineraryBudget_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EstimateItineraryBudgetReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentServer).EstimateItineraryBudget(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Content_EstimateItineraryBudget_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentServer).EstimateItineraryBudget(ctx, req.(*EstimateItineraryBudgetReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedContentServer) GetAllStories(context.Context, *GetAllStoriesReq) (*GetAllStoriesRes, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetAllStories not implemented")
+
+func _Content_GetNearby_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNearbyReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentServer).GetNearby(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Content_GetNearby_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentServer).GetNearby(ctx, req.(*GetNearbyReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedContentServer) GetStory(context.Context, *StoryId) (*GetStoryRes, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetStory not implemented")
+
+func _Content_ExportItinerary_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportItineraryReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ContentServer).ExportItinerary(m, &contentExportItineraryServer{ServerStream: stream})
 }
-func (UnimplementedContentServer) CommentStory(context.Context, *CommentStoryReq) (*CommentStoryRes, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CommentStory not implemented")
+
+type Content_ExportItineraryServer interface {
+	Send(*ExportChunk) error
+	grpc.ServerStream
 }
-func (UnimplementedContentServer) GetCommentsOfStory(context.Context, *GetCommentsOfStoryReq) (*GetCommentsOfStoryRes, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetCommentsOfStory not implemented")
+
+type contentExportItineraryServer struct {
+	grpc.ServerStream
 }
-func (UnimplementedContentServer) Like(context.Context, *LikeReq) (*LikeRes, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Like not implemented")
+
+func (x *contentExportItineraryServer) Send(m *ExportChunk) error {
+	return x.ServerStream.SendMsg(m)
 }
-func (UnimplementedContentServer) Itineraries(context.Context, *ItinerariesReq) (*ItinerariesRes, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Itineraries not implemented")
+
+func _Content_ExportStories_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportStoriesReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ContentServer).ExportStories(m, &contentExportStoriesServer{ServerStream: stream})
 }
-func (UnimplementedContentServer) UpdateItineraries(context.Context, *UpdateItinerariesReq) (*ItinerariesRes, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateItineraries not implemented")
+
+type Content_ExportStoriesServer interface {
+	Send(*ExportChunk) error
+	grpc.ServerStream
 }
-func (UnimplementedContentServer) DeleteItineraries(context.Context, *StoryId) (*Void, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteItineraries not implemented")
+
+type contentExportStoriesServer struct {
+	grpc.ServerStream
 }
-func (UnimplementedContentServer) GetItineraries(context.Context, *GetItinerariesReq) (*GetItinerariesRes, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetItineraries not implemented")
+
+func (x *contentExportStoriesServer) Send(m *ExportChunk) error {
+	return x.ServerStream.SendMsg(m)
 }
-func (UnimplementedContentServer) GetItinerariesById(context.Context, *StoryId) (*GetItinerariesByIdRes, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetItinerariesById not implemented")
+
+func _Content_CheckItinerary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckItineraryReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentServer).CheckItinerary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Content_CheckItinerary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentServer).CheckItinerary(ctx, req.(*CheckItineraryReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedContentServer) CommentItineraries(context.Context, *CommentItinerariesReq) (*CommentItinerariesRes, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CommentItineraries not implemented")
+
+func _Content_GetItinerarySchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetItineraryScheduleReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentServer).GetItinerarySchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Content_GetItinerarySchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentServer).GetItinerarySchedule(ctx, req.(*GetItineraryScheduleReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedContentServer) GetDestinations(context.Context, *GetDestinationsReq) (*GetDestinationsRes, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetDestinations not implemented")
+
+func _Content_CreateShareLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateShareLinkReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentServer).CreateShareLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Content_CreateShareLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentServer).CreateShareLink(ctx, req.(*CreateShareLinkReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedContentServer) GetDestinationsById(context.Context, *GetDestinationsByIdReq) (*GetDestinationsByIdRes, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetDestinationsById not implemented")
+
+func _Content_RevokeShareLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeShareLinkReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentServer).RevokeShareLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Content_RevokeShareLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentServer).RevokeShareLink(ctx, req.(*RevokeShareLinkReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedContentServer) SendMessage(context.Context, *SendMessageReq) (*SendMessageRes, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SendMessage not implemented")
+
+func _Content_InviteCollaborator_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InviteCollaboratorReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentServer).InviteCollaborator(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Content_InviteCollaborator_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentServer).InviteCollaborator(ctx, req.(*InviteCollaboratorReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedContentServer) GetMessages(context.Context, *GetMessagesReq) (*GetMessagesRes, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetMessages not implemented")
+
+func _Content_AcceptInvite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcceptInviteReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentServer).AcceptInvite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Content_AcceptInvite_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentServer).AcceptInvite(ctx, req.(*AcceptInviteReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedContentServer) CreateTips(context.Context, *CreateTipsReq) (*CreateTipsRes, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateTips not implemented")
+
+func _Content_RemoveCollaborator_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveCollaboratorReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentServer).RemoveCollaborator(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Content_RemoveCollaborator_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentServer).RemoveCollaborator(ctx, req.(*RemoveCollaboratorReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedContentServer) GetTips(context.Context, *GetTipsReq) (*GetTipsRes, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetTips not implemented")
+
+func _Content_AddItineraryDestination_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddItineraryDestinationReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentServer).AddItineraryDestination(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Content_AddItineraryDestination_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentServer).AddItineraryDestination(ctx, req.(*AddItineraryDestinationReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedContentServer) GetUserStat(context.Context, *GetUserStatReq) (*GetUserStatRes, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetUserStat not implemented")
+
+func _Content_UpdateItineraryDestination_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateItineraryDestinationReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentServer).UpdateItineraryDestination(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Content_UpdateItineraryDestination_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentServer).UpdateItineraryDestination(ctx, req.(*UpdateItineraryDestinationReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Content_RemoveItineraryDestination_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveItineraryChildReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentServer).RemoveItineraryDestination(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Content_RemoveItineraryDestination_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentServer).RemoveItineraryDestination(ctx, req.(*RemoveItineraryChildReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedContentServer) TopDestinations(context.Context, *Void) (*Answer, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method TopDestinations not implemented")
+
+func _Content_AddItineraryActivity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddItineraryActivityReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentServer).AddItineraryActivity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Content_AddItineraryActivity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentServer).AddItineraryActivity(ctx, req.(*AddItineraryActivityReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedContentServer) mustEmbedUnimplementedContentServer() {}
 
-// UnsafeContentServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to ContentServer will
-// result in compilation errors.
-type UnsafeContentServer interface {
-	mustEmbedUnimplementedContentServer()
+func _Content_UpdateItineraryActivity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateItineraryActivityReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentServer).UpdateItineraryActivity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Content_UpdateItineraryActivity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentServer).UpdateItineraryActivity(ctx, req.(*UpdateItineraryActivityReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterContentServer(s grpc.ServiceRegistrar, srv ContentServer) {
-	s.RegisterService(&Content_ServiceDesc, srv)
+func _Content_RemoveItineraryActivity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveItineraryChildReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentServer).RemoveItineraryActivity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Content_RemoveItineraryActivity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentServer).RemoveItineraryActivity(ctx, req.(*RemoveItineraryChildReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _Content_CreateStories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateStoriesRequest)
+func _Content_ListStoryRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStoryRevisionsReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ContentServer).CreateStories(ctx, in)
+		return srv.(ContentServer).ListStoryRevisions(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Content_CreateStories_FullMethodName,
+		FullMethod: Content_ListStoryRevisions_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ContentServer).CreateStories(ctx, req.(*CreateStoriesRequest))
+		return srv.(ContentServer).ListStoryRevisions(ctx, req.(*ListStoryRevisionsReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Content_UpdateStories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateStoriesReq)
+func _Content_GetStoryRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStoryRevisionReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ContentServer).UpdateStories(ctx, in)
+		return srv.(ContentServer).GetStoryRevision(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Content_UpdateStories_FullMethodName,
+		FullMethod: Content_GetStoryRevision_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ContentServer).UpdateStories(ctx, req.(*UpdateStoriesReq))
+		return srv.(ContentServer).GetStoryRevision(ctx, req.(*GetStoryRevisionReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Content_DeleteStories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(StoryId)
+func _Content_RevertStory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevertStoryReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ContentServer).DeleteStories(ctx, in)
+		return srv.(ContentServer).RevertStory(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Content_DeleteStories_FullMethodName,
+		FullMethod: Content_RevertStory_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ContentServer).DeleteStories(ctx, req.(*StoryId))
+		return srv.(ContentServer).RevertStory(ctx, req.(*RevertStoryReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Content_GetAllStories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetAllStoriesReq)
+func _Content_PublishStory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishStoryReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ContentServer).GetAllStories(ctx, in)
+		return srv.(ContentServer).PublishStory(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Content_GetAllStories_FullMethodName,
+		FullMethod: Content_PublishStory_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ContentServer).GetAllStories(ctx, req.(*GetAllStoriesReq))
+		return srv.(ContentServer).PublishStory(ctx, req.(*PublishStoryReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Content_GetStory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(StoryId)
+func _Content_RestoreStory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreStoryReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ContentServer).GetStory(ctx, in)
+		return srv.(ContentServer).RestoreStory(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Content_GetStory_FullMethodName,
+		FullMethod: Content_RestoreStory_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ContentServer).GetStory(ctx, req.(*StoryId))
+		return srv.(ContentServer).RestoreStory(ctx, req.(*RestoreStoryReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Content_CommentStory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CommentStoryReq)
+func _Content_RestoreItinerary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreItineraryReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ContentServer).CommentStory(ctx, in)
+		return srv.(ContentServer).RestoreItinerary(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Content_CommentStory_FullMethodName,
+		FullMethod: Content_RestoreItinerary_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ContentServer).CommentStory(ctx, req.(*CommentStoryReq))
+		return srv.(ContentServer).RestoreItinerary(ctx, req.(*RestoreItineraryReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Content_GetCommentsOfStory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetCommentsOfStoryReq)
+func _Content_ListTrash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTrashReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ContentServer).GetCommentsOfStory(ctx, in)
+		return srv.(ContentServer).ListTrash(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Content_GetCommentsOfStory_FullMethodName,
+		FullMethod: Content_ListTrash_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ContentServer).GetCommentsOfStory(ctx, req.(*GetCommentsOfStoryReq))
+		return srv.(ContentServer).ListTrash(ctx, req.(*ListTrashReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Content_Like_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(LikeReq)
+func _Content_UploadMedia_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ContentServer).UploadMedia(&contentUploadMediaServer{ServerStream: stream})
+}
+
+type Content_UploadMediaServer interface {
+	SendAndClose(*Media) error
+	Recv() (*UploadMediaReq, error)
+	grpc.ServerStream
+}
+
+type contentUploadMediaServer struct {
+	grpc.ServerStream
+}
+
+func (x *contentUploadMediaServer) SendAndClose(m *Media) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *contentUploadMediaServer) Recv() (*UploadMediaReq, error) {
+	m := new(UploadMediaReq)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Content_DownloadMedia_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadMediaReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ContentServer).DownloadMedia(m, &contentDownloadMediaServer{ServerStream: stream})
+}
+
+type Content_DownloadMediaServer interface {
+	Send(*ExportChunk) error
+	grpc.ServerStream
+}
+
+type contentDownloadMediaServer struct {
+	grpc.ServerStream
+}
+
+func (x *contentDownloadMediaServer) Send(m *ExportChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Content_ListMentions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMentionsReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ContentServer).Like(ctx, in)
+		return srv.(ContentServer).ListMentions(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Content_Like_FullMethodName,
+		FullMethod: Content_ListMentions_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ContentServer).Like(ctx, req.(*LikeReq))
+		return srv.(ContentServer).ListMentions(ctx, req.(*ListMentionsReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Content_Itineraries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ItinerariesReq)
+func _Content_ListTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTagsReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ContentServer).Itineraries(ctx, in)
+		return srv.(ContentServer).ListTags(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Content_Itineraries_FullMethodName,
+		FullMethod: Content_ListTags_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ContentServer).Itineraries(ctx, req.(*ItinerariesReq))
+		return srv.(ContentServer).ListTags(ctx, req.(*ListTagsReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Content_UpdateItineraries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateItinerariesReq)
+func _Content_GetTrendingTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTrendingTagsReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ContentServer).UpdateItineraries(ctx, in)
+		return srv.(ContentServer).GetTrendingTags(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Content_UpdateItineraries_FullMethodName,
+		FullMethod: Content_GetTrendingTags_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ContentServer).UpdateItineraries(ctx, req.(*UpdateItinerariesReq))
+		return srv.(ContentServer).GetTrendingTags(ctx, req.(*GetTrendingTagsReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Content_DeleteItineraries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(StoryId)
+func _Content_CreateCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCollectionReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ContentServer).DeleteItineraries(ctx, in)
+		return srv.(ContentServer).CreateCollection(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Content_DeleteItineraries_FullMethodName,
+		FullMethod: Content_CreateCollection_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ContentServer).DeleteItineraries(ctx, req.(*StoryId))
+		return srv.(ContentServer).CreateCollection(ctx, req.(*CreateCollectionReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Content_GetItineraries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetItinerariesReq)
+func _Content_AddToCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CollectionItemReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ContentServer).GetItineraries(ctx, in)
+		return srv.(ContentServer).AddToCollection(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Content_GetItineraries_FullMethodName,
+		FullMethod: Content_AddToCollection_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ContentServer).GetItineraries(ctx, req.(*GetItinerariesReq))
+		return srv.(ContentServer).AddToCollection(ctx, req.(*CollectionItemReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Content_GetItinerariesById_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(StoryId)
+func _Content_RemoveFromCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CollectionItemReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ContentServer).GetItinerariesById(ctx, in)
+		return srv.(ContentServer).RemoveFromCollection(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Content_GetItinerariesById_FullMethodName,
+		FullMethod: Content_RemoveFromCollection_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ContentServer).GetItinerariesById(ctx, req.(*StoryId))
+		return srv.(ContentServer).RemoveFromCollection(ctx, req.(*CollectionItemReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Content_CommentItineraries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CommentItinerariesReq)
+func _Content_ListCollections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCollectionsReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ContentServer).CommentItineraries(ctx, in)
+		return srv.(ContentServer).ListCollections(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Content_CommentItineraries_FullMethodName,
+		FullMethod: Content_ListCollections_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ContentServer).CommentItineraries(ctx, req.(*CommentItinerariesReq))
+		return srv.(ContentServer).ListCollections(ctx, req.(*ListCollectionsReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Content_GetDestinations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetDestinationsReq)
+func _Content_GetCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCollectionReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ContentServer).GetDestinations(ctx, in)
+		return srv.(ContentServer).GetCollection(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Content_GetDestinations_FullMethodName,
+		FullMethod: Content_GetCollection_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ContentServer).GetDestinations(ctx, req.(*GetDestinationsReq))
+		return srv.(ContentServer).GetCollection(ctx, req.(*GetCollectionReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Content_GetDestinationsById_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetDestinationsByIdReq)
+func _Content_CreateSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSeriesReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ContentServer).GetDestinationsById(ctx, in)
+		return srv.(ContentServer).CreateSeries(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Content_GetDestinationsById_FullMethodName,
+		FullMethod: Content_CreateSeries_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ContentServer).GetDestinationsById(ctx, req.(*GetDestinationsByIdReq))
+		return srv.(ContentServer).CreateSeries(ctx, req.(*CreateSeriesReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Content_SendMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SendMessageReq)
+func _Content_AddStoryToSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddStoryToSeriesReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ContentServer).SendMessage(ctx, in)
+		return srv.(ContentServer).AddStoryToSeries(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Content_SendMessage_FullMethodName,
+		FullMethod: Content_AddStoryToSeries_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ContentServer).SendMessage(ctx, req.(*SendMessageReq))
+		return srv.(ContentServer).AddStoryToSeries(ctx, req.(*AddStoryToSeriesReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Content_GetMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetMessagesReq)
+func _Content_ReorderSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReorderSeriesReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ContentServer).GetMessages(ctx, in)
+		return srv.(ContentServer).ReorderSeries(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Content_GetMessages_FullMethodName,
+		FullMethod: Content_ReorderSeries_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ContentServer).GetMessages(ctx, req.(*GetMessagesReq))
+		return srv.(ContentServer).ReorderSeries(ctx, req.(*ReorderSeriesReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Content_CreateTips_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateTipsReq)
+func _Content_RecordView_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordViewReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ContentServer).CreateTips(ctx, in)
+		return srv.(ContentServer).RecordView(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Content_CreateTips_FullMethodName,
+		FullMethod: Content_RecordView_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ContentServer).CreateTips(ctx, req.(*CreateTipsReq))
+		return srv.(ContentServer).RecordView(ctx, req.(*RecordViewReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Content_GetTips_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetTipsReq)
+func _Content_GetTrendingStories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTrendingStoriesReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ContentServer).GetTips(ctx, in)
+		return srv.(ContentServer).GetTrendingStories(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Content_GetTips_FullMethodName,
+		FullMethod: Content_GetTrendingStories_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ContentServer).GetTips(ctx, req.(*GetTipsReq))
+		return srv.(ContentServer).GetTrendingStories(ctx, req.(*GetTrendingStoriesReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Content_GetUserStat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetUserStatReq)
+func _Content_GetFeed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFeedReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ContentServer).GetUserStat(ctx, in)
+		return srv.(ContentServer).GetFeed(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Content_GetUserStat_FullMethodName,
+		FullMethod: Content_GetFeed_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ContentServer).GetUserStat(ctx, req.(*GetUserStatReq))
+		return srv.(ContentServer).GetFeed(ctx, req.(*GetFeedReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Content_TopDestinations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Void)
+func _Content_GetRelatedStories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRelatedStoriesReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ContentServer).TopDestinations(ctx, in)
+		return srv.(ContentServer).GetRelatedStories(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Content_TopDestinations_FullMethodName,
+		FullMethod: Content_GetRelatedStories_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ContentServer).TopDestinations(ctx, req.(*Void))
+		return srv.(ContentServer).GetRelatedStories(ctx, req.(*GetRelatedStoriesReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -902,7 +2407,176 @@ var Content_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "TopDestinations",
 			Handler:    _Content_TopDestinations_Handler,
 		},
+		{
+			MethodName: "EstimateItineraryBudget",
+			Handler:    _Content_EstimateItineraryBudget_Handler,
+		},
+		{
+			MethodName: "GetNearby",
+			Handler:    _Content_GetNearby_Handler,
+		},
+		{
+			MethodName: "CheckItinerary",
+			Handler:    _Content_CheckItinerary_Handler,
+		},
+		{
+			MethodName: "GetItinerarySchedule",
+			Handler:    _Content_GetItinerarySchedule_Handler,
+		},
+		{
+			MethodName: "CreateShareLink",
+			Handler:    _Content_CreateShareLink_Handler,
+		},
+		{
+			MethodName: "RevokeShareLink",
+			Handler:    _Content_RevokeShareLink_Handler,
+		},
+		{
+			MethodName: "InviteCollaborator",
+			Handler:    _Content_InviteCollaborator_Handler,
+		},
+		{
+			MethodName: "AcceptInvite",
+			Handler:    _Content_AcceptInvite_Handler,
+		},
+		{
+			MethodName: "RemoveCollaborator",
+			Handler:    _Content_RemoveCollaborator_Handler,
+		},
+		{
+			MethodName: "AddItineraryDestination",
+			Handler:    _Content_AddItineraryDestination_Handler,
+		},
+		{
+			MethodName: "UpdateItineraryDestination",
+			Handler:    _Content_UpdateItineraryDestination_Handler,
+		},
+		{
+			MethodName: "RemoveItineraryDestination",
+			Handler:    _Content_RemoveItineraryDestination_Handler,
+		},
+		{
+			MethodName: "AddItineraryActivity",
+			Handler:    _Content_AddItineraryActivity_Handler,
+		},
+		{
+			MethodName: "UpdateItineraryActivity",
+			Handler:    _Content_UpdateItineraryActivity_Handler,
+		},
+		{
+			MethodName: "RemoveItineraryActivity",
+			Handler:    _Content_RemoveItineraryActivity_Handler,
+		},
+		{
+			MethodName: "ListStoryRevisions",
+			Handler:    _Content_ListStoryRevisions_Handler,
+		},
+		{
+			MethodName: "GetStoryRevision",
+			Handler:    _Content_GetStoryRevision_Handler,
+		},
+		{
+			MethodName: "RevertStory",
+			Handler:    _Content_RevertStory_Handler,
+		},
+		{
+			MethodName: "PublishStory",
+			Handler:    _Content_PublishStory_Handler,
+		},
+		{
+			MethodName: "RestoreStory",
+			Handler:    _Content_RestoreStory_Handler,
+		},
+		{
+			MethodName: "RestoreItinerary",
+			Handler:    _Content_RestoreItinerary_Handler,
+		},
+		{
+			MethodName: "ListTrash",
+			Handler:    _Content_ListTrash_Handler,
+		},
+		{
+			MethodName: "ListMentions",
+			Handler:    _Content_ListMentions_Handler,
+		},
+		{
+			MethodName: "ListTags",
+			Handler:    _Content_ListTags_Handler,
+		},
+		{
+			MethodName: "GetTrendingTags",
+			Handler:    _Content_GetTrendingTags_Handler,
+		},
+		{
+			MethodName: "CreateCollection",
+			Handler:    _Content_CreateCollection_Handler,
+		},
+		{
+			MethodName: "AddToCollection",
+			Handler:    _Content_AddToCollection_Handler,
+		},
+		{
+			MethodName: "RemoveFromCollection",
+			Handler:    _Content_RemoveFromCollection_Handler,
+		},
+		{
+			MethodName: "ListCollections",
+			Handler:    _Content_ListCollections_Handler,
+		},
+		{
+			MethodName: "GetCollection",
+			Handler:    _Content_GetCollection_Handler,
+		},
+		{
+			MethodName: "CreateSeries",
+			Handler:    _Content_CreateSeries_Handler,
+		},
+		{
+			MethodName: "AddStoryToSeries",
+			Handler:    _Content_AddStoryToSeries_Handler,
+		},
+		{
+			MethodName: "ReorderSeries",
+			Handler:    _Content_ReorderSeries_Handler,
+		},
+		{
+			MethodName: "RecordView",
+			Handler:    _Content_RecordView_Handler,
+		},
+		{
+			MethodName: "GetTrendingStories",
+			Handler:    _Content_GetTrendingStories_Handler,
+		},
+		{
+			MethodName: "GetFeed",
+			Handler:    _Content_GetFeed_Handler,
+		},
+		{
+			MethodName: "GetRelatedStories",
+			Handler:    _Content_GetRelatedStories_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportItinerary",
+			Handler:       _Content_ExportItinerary_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportStories",
+			Handler:       _Content_ExportStories_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "UploadMedia",
+			Handler:       _Content_UploadMedia_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "DownloadMedia",
+			Handler:       _Content_DownloadMedia_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "content.proto",
 }
@@ -0,0 +1,32 @@
+package help
+
+import (
+	"math"
+	"strings"
+)
+
+// ConvertCurrency converts amount between two currencies using a table of
+// rates expressed as units of each currency per one US dollar. The second
+// result is false when either currency is missing from the table.
+func ConvertCurrency(amount float64, from, to string, unitsPerUSD map[string]float64) (float64, bool) {
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	if from == to {
+		return amount, true
+	}
+
+	fromRate, ok := unitsPerUSD[from]
+	if !ok || fromRate <= 0 {
+		return 0, false
+	}
+	toRate, ok := unitsPerUSD[to]
+	if !ok || toRate <= 0 {
+		return 0, false
+	}
+
+	return RoundMoney(amount / fromRate * toRate), true
+}
+
+// RoundMoney rounds an amount to two decimal places.
+func RoundMoney(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
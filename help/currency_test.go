@@ -0,0 +1,28 @@
+package help
+
+import "testing"
+
+func TestConvertCurrency(t *testing.T) {
+	rates := map[string]float64{"USD": 1, "EUR": 0.5, "UZS": 12000}
+
+	tests := []struct {
+		amount   float64
+		from, to string
+		want     float64
+		ok       bool
+	}{
+		{100, "USD", "USD", 100, true},
+		{100, "usd", "EUR", 50, true},
+		{50, "EUR", "UZS", 1200000, true},
+		{10, "EUR", "USD", 20, true},
+		{10, "GBP", "USD", 0, false},
+		{10, "USD", "GBP", 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := ConvertCurrency(tt.amount, tt.from, tt.to, rates)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("ConvertCurrency(%v, %s, %s) = %v, %v, want %v, %v", tt.amount, tt.from, tt.to, got, ok, tt.want, tt.ok)
+		}
+	}
+}
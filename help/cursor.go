@@ -0,0 +1,68 @@
+package help
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// FeedCursor is a position in a feed ordered newest first by time, then by
+// item type and item id so that items with the same time keep a stable
+// order across pages.
+type FeedCursor struct {
+	Time     time.Time
+	ItemType string
+	ItemID   string
+}
+
+// IsZero reports whether c is the start of the feed.
+func (c FeedCursor) IsZero() bool {
+	return c.Time.IsZero()
+}
+
+// Less reports whether c comes after o in the feed.
+func (c FeedCursor) Less(o FeedCursor) bool {
+	if !c.Time.Equal(o.Time) {
+		return c.Time.Before(o.Time)
+	}
+	if c.ItemType != o.ItemType {
+		return c.ItemType < o.ItemType
+	}
+	return c.ItemID < o.ItemID
+}
+
+// String encodes c as an opaque token for clients. Times keep microsecond
+// precision, which is what Postgres stores.
+func (c FeedCursor) String() string {
+	if c.IsZero() {
+		return ""
+	}
+	raw := strings.Join([]string{strconv.FormatInt(c.Time.UnixMicro(), 10), c.ItemType, c.ItemID}, "|")
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParseFeedCursor decodes a token made by FeedCursor.String. The empty
+// token is the start of the feed. Tokens naming anything but a story or
+// itinerary id are rejected, since the cursor is bound into feed queries.
+func ParseFeedCursor(token string) (FeedCursor, error) {
+	if token == "" {
+		return FeedCursor{}, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return FeedCursor{}, ErrInvalidCursor
+	}
+	parts := strings.Split(string(raw), "|")
+	if len(parts) != 3 || (parts[1] != "story" && parts[1] != "itinerary") || !IsUUID(parts[2]) {
+		return FeedCursor{}, ErrInvalidCursor
+	}
+	micros, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil || micros <= 0 {
+		return FeedCursor{}, ErrInvalidCursor
+	}
+	return FeedCursor{Time: time.UnixMicro(micros).UTC(), ItemType: parts[1], ItemID: parts[2]}, nil
+}
@@ -0,0 +1,57 @@
+package help
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+)
+
+func TestFeedCursorRoundTrip(t *testing.T) {
+	c := FeedCursor{
+		Time:     time.Date(2024, 5, 6, 7, 8, 9, 123456000, time.UTC),
+		ItemType: "story",
+		ItemID:   "4b1c7e0a-95d2-4a8e-9f1d-2a6f0c3b8e11",
+	}
+	got, err := ParseFeedCursor(c.String())
+	if err != nil {
+		t.Fatalf("ParseFeedCursor: %v", err)
+	}
+	if !got.Time.Equal(c.Time) || got.ItemType != c.ItemType || got.ItemID != c.ItemID {
+		t.Errorf("round trip = %+v, want %+v", got, c)
+	}
+
+	if c, err := ParseFeedCursor(""); err != nil || !c.IsZero() {
+		t.Errorf("ParseFeedCursor(\"\") = %+v, %v, want the start of the feed", c, err)
+	}
+	invalid := []string{
+		"not base64!",
+		"c3Rvcnk",
+		"MTIzfHN0b3J5fA",
+		base64.RawURLEncoding.EncodeToString([]byte("123|story|x")),
+		base64.RawURLEncoding.EncodeToString([]byte("123|tip|" + c.ItemID)),
+	}
+	for _, token := range invalid {
+		if _, err := ParseFeedCursor(token); err != ErrInvalidCursor {
+			t.Errorf("ParseFeedCursor(%q) error = %v, want ErrInvalidCursor", token, err)
+		}
+	}
+}
+
+func TestFeedCursorLess(t *testing.T) {
+	now := time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		a, b FeedCursor
+		want bool
+	}{
+		{FeedCursor{now.Add(-time.Second), "story", "b"}, FeedCursor{now, "story", "a"}, true},
+		{FeedCursor{now, "itinerary", "b"}, FeedCursor{now, "story", "a"}, true},
+		{FeedCursor{now, "story", "a"}, FeedCursor{now, "story", "b"}, true},
+		{FeedCursor{now, "story", "a"}, FeedCursor{now, "story", "a"}, false},
+		{FeedCursor{now, "story", "a"}, FeedCursor{now.Add(-time.Second), "story", "b"}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.a.Less(tt.b); got != tt.want {
+			t.Errorf("%+v.Less(%+v) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package help
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func render(diff []DiffLine) []string {
+	var out []string
+	for _, line := range diff {
+		prefix := " "
+		switch line.Op {
+		case DiffInsert:
+			prefix = "+"
+		case DiffDelete:
+			prefix = "-"
+		}
+		out = append(out, prefix+line.Text)
+	}
+	return out
+}
+
+func TestDiffLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new string
+		want     []string
+	}{
+		{"identical", "a\nb", "a\nb", []string{" a", " b"}},
+		{"both empty", "", "", nil},
+		{"from empty", "", "a\nb", []string{"+a", "+b"}},
+		{"to empty", "a", "", []string{"-a"}},
+		{"changed middle", "a\nb\nc", "a\nx\nc", []string{" a", "-b", "+x", " c"}},
+		{"insert and delete", "a\nb\nc\nd", "b\nc\ne\nd", []string{"-a", " b", " c", "+e", " d"}},
+		{"crlf", "a\r\nb", "a\nb", []string{" a", " b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := render(DiffLines(tt.old, tt.new)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DiffLines(%q, %q) = %q, want %q", tt.old, tt.new, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffLinesLargeInputFallsBack(t *testing.T) {
+	var a, b []string
+	for i := 0; i < 2100; i++ {
+		a = append(a, "old "+strings.Repeat("x", i%7))
+		b = append(b, "new "+strings.Repeat("y", i%5))
+	}
+
+	diff := DiffLines(strings.Join(a, "\n"), strings.Join(b, "\n"))
+	if len(diff) != len(a)+len(b) {
+		t.Fatalf("got %d lines, want %d", len(diff), len(a)+len(b))
+	}
+	if diff[0].Op != DiffDelete || diff[len(diff)-1].Op != DiffInsert {
+		t.Errorf("got first op %q and last op %q, want delete then insert", diff[0].Op, diff[len(diff)-1].Op)
+	}
+}
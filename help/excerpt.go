@@ -0,0 +1,51 @@
+package help
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Excerpt collapses the whitespace in text and shortens it to at most
+// maxRunes runes, cutting at a word boundary where possible and marking the
+// cut with an ellipsis.
+func Excerpt(text string, maxRunes int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	runes := []rune(text)
+	if len(runes) <= maxRunes {
+		return text
+	}
+	if maxRunes <= 0 {
+		return ""
+	}
+
+	cut := runes[:maxRunes-1]
+	if i := lastSpace(cut); i > len(cut)/2 {
+		cut = cut[:i]
+	}
+	return strings.TrimRightFunc(string(cut), func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r)
+	}) + "…"
+}
+
+// readingWordsPerMinute is the reading speed assumed by ReadingMinutes.
+const readingWordsPerMinute = 200
+
+// WordCount returns the number of whitespace-separated words in text.
+func WordCount(text string) int {
+	return len(strings.Fields(text))
+}
+
+// ReadingMinutes estimates how many minutes it takes to read words words,
+// rounded up so that any text takes at least a minute.
+func ReadingMinutes(words int) int {
+	return (words + readingWordsPerMinute - 1) / readingWordsPerMinute
+}
+
+func lastSpace(runes []rune) int {
+	for i := len(runes) - 1; i >= 0; i-- {
+		if runes[i] == ' ' {
+			return i
+		}
+	}
+	return -1
+}
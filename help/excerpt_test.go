@@ -0,0 +1,37 @@
+package help
+
+import "testing"
+
+func TestExcerpt(t *testing.T) {
+	tests := []struct {
+		text string
+		max  int
+		want string
+	}{
+		{"short  text\n", 20, "short text"},
+		{"a walk along the old city walls", 20, "a walk along the…"},
+		{"unbrokenwordthatislong", 10, "unbrokenw…"},
+		{"end of sentence. Next one", 17, "end of sentence…"},
+		{"ünïcödé wörds ärë cöüntëd", 12, "ünïcödé…"},
+	}
+	for _, tt := range tests {
+		if got := Excerpt(tt.text, tt.max); got != tt.want {
+			t.Errorf("Excerpt(%q, %d) = %q, want %q", tt.text, tt.max, got, tt.want)
+		}
+		if n := len([]rune(Excerpt(tt.text, tt.max))); n > tt.max {
+			t.Errorf("Excerpt(%q, %d) has %d runes", tt.text, tt.max, n)
+		}
+	}
+}
+
+func TestReadingTime(t *testing.T) {
+	if n := WordCount("  one two\nthree\tfour "); n != 4 {
+		t.Errorf("WordCount = %d, want 4", n)
+	}
+	tests := map[int]int{0: 0, 1: 1, 200: 1, 201: 2, 1000: 5}
+	for words, want := range tests {
+		if got := ReadingMinutes(words); got != want {
+			t.Errorf("ReadingMinutes(%d) = %d, want %d", words, got, want)
+		}
+	}
+}
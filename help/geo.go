@@ -0,0 +1,88 @@
+package help
+
+import (
+	"errors"
+	"math"
+)
+
+// EarthRadiusKm is the mean radius of the Earth used for great-circle distances.
+const EarthRadiusKm = 6371.0
+
+var (
+	ErrPartialCoordinates = errors.New("latitude and longitude must be set together")
+	ErrLatitudeRange      = errors.New("latitude must be between -90 and 90")
+	ErrLongitudeRange     = errors.New("longitude must be between -180 and 180")
+)
+
+// ValidateCoordinates checks an optional coordinate pair. Both values must be
+// either set or unset, and set values must lie within their valid ranges.
+func ValidateCoordinates(latitude, longitude *float64) error {
+	if latitude == nil && longitude == nil {
+		return nil
+	}
+	if latitude == nil || longitude == nil {
+		return ErrPartialCoordinates
+	}
+	return ValidatePoint(*latitude, *longitude)
+}
+
+// ValidatePoint checks that a latitude and longitude lie within their ranges.
+func ValidatePoint(latitude, longitude float64) error {
+	if math.IsNaN(latitude) || latitude < -90 || latitude > 90 {
+		return ErrLatitudeRange
+	}
+	if math.IsNaN(longitude) || longitude < -180 || longitude > 180 {
+		return ErrLongitudeRange
+	}
+	return nil
+}
+
+// Haversine returns the great-circle distance in kilometres between two points.
+func Haversine(lat1, lng1, lat2, lng2 float64) float64 {
+	dLat := toRadians(lat2 - lat1)
+	dLng := toRadians(lng2 - lng1)
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRadians(lat1))*math.Cos(toRadians(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+
+	return 2 * EarthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// BoundingBox is a latitude/longitude rectangle enclosing a search circle.
+type BoundingBox struct {
+	MinLat, MaxLat float64
+	MinLng, MaxLng float64
+}
+
+// BoundingBoxAround returns a box that contains every point within radiusKm of
+// the given centre. When the circle reaches a pole or crosses the
+// antimeridian the box spans every longitude, so it can always be used as a
+// simple BETWEEN filter.
+func BoundingBoxAround(latitude, longitude, radiusKm float64) BoundingBox {
+	dLat := radiusKm / EarthRadiusKm * 180 / math.Pi
+
+	box := BoundingBox{
+		MinLat: latitude - dLat,
+		MaxLat: latitude + dLat,
+		MinLng: -180,
+		MaxLng: 180,
+	}
+
+	if box.MinLat <= -90 || box.MaxLat >= 90 {
+		box.MinLat = math.Max(box.MinLat, -90)
+		box.MaxLat = math.Min(box.MaxLat, 90)
+		return box
+	}
+
+	dLng := math.Asin(math.Sin(radiusKm/EarthRadiusKm)/math.Cos(toRadians(latitude))) * 180 / math.Pi
+	if longitude-dLng >= -180 && longitude+dLng <= 180 {
+		box.MinLng = longitude - dLng
+		box.MaxLng = longitude + dLng
+	}
+
+	return box
+}
+
+func toRadians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
@@ -0,0 +1,55 @@
+package help
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHaversine(t *testing.T) {
+	// Tashkent to Samarkand is roughly 270 km as the crow flies.
+	got := Haversine(41.2995, 69.2401, 39.6542, 66.9597)
+	if math.Abs(got-266) > 5 {
+		t.Errorf("Haversine(Tashkent, Samarkand) = %.1f, want about 266", got)
+	}
+
+	if got := Haversine(10, 20, 10, 20); got != 0 {
+		t.Errorf("Haversine of identical points = %v, want 0", got)
+	}
+}
+
+func TestBoundingBoxAround(t *testing.T) {
+	box := BoundingBoxAround(41.3, 69.2, 100)
+	for _, p := range [][2]float64{{42.1, 69.2}, {40.5, 69.2}, {41.3, 70.3}, {41.3, 68.1}} {
+		if Haversine(41.3, 69.2, p[0], p[1]) <= 100 &&
+			(p[0] < box.MinLat || p[0] > box.MaxLat || p[1] < box.MinLng || p[1] > box.MaxLng) {
+			t.Errorf("point %v within radius lies outside box %+v", p, box)
+		}
+	}
+
+	box = BoundingBoxAround(0, 179.9, 50)
+	if box.MinLng != -180 || box.MaxLng != 180 {
+		t.Errorf("box crossing the antimeridian = %+v, want full longitude span", box)
+	}
+
+	box = BoundingBoxAround(89.9, 0, 50)
+	if box.MaxLat != 90 || box.MinLng != -180 || box.MaxLng != 180 {
+		t.Errorf("box reaching the pole = %+v, want capped latitude and full longitude span", box)
+	}
+}
+
+func TestValidateCoordinates(t *testing.T) {
+	lat, lng, bad := 41.3, 69.2, 91.0
+
+	if err := ValidateCoordinates(nil, nil); err != nil {
+		t.Errorf("unset coordinates: %v", err)
+	}
+	if err := ValidateCoordinates(&lat, &lng); err != nil {
+		t.Errorf("valid coordinates: %v", err)
+	}
+	if err := ValidateCoordinates(&lat, nil); err != ErrPartialCoordinates {
+		t.Errorf("partial coordinates = %v, want %v", err, ErrPartialCoordinates)
+	}
+	if err := ValidateCoordinates(&bad, &lng); err != ErrLatitudeRange {
+		t.Errorf("latitude out of range = %v, want %v", err, ErrLatitudeRange)
+	}
+}
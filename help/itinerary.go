@@ -0,0 +1,227 @@
+package help
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	IssueInvalidDate   = "invalid_date"
+	IssueInvertedRange = "inverted_range"
+	IssueOutOfRange    = "out_of_range"
+	IssueOverlap       = "overlap"
+	IssueGap           = "gap"
+	IssueNoActivities  = "no_activities"
+	IssueActivityDay   = "activity_day_out_of_range"
+)
+
+const day = 24 * time.Hour
+
+// Stay is one destination of an itinerary as seen by CheckItineraryDates.
+// Activities counts the activities not tied to a particular day, which are
+// taken to fill every day of the stay; ActivityDays lists the 1-based day
+// index of each activity that has one.
+type Stay struct {
+	Name         string
+	StartDate    string
+	EndDate      string
+	Activities   int
+	ActivityDays []int
+}
+
+// Issue describes a problem found in an itinerary. StartDate and EndDate
+// hold the affected date range when there is one.
+type Issue struct {
+	Code        string
+	Message     string
+	Destination string
+	StartDate   string
+	EndDate     string
+}
+
+// CheckItineraryDates validates the trip dates and destination stays of an
+// itinerary. Errors are problems that make the itinerary inconsistent:
+// unparsable dates, ranges ending before they start, stays outside the trip,
+// stays overlapping each other and activities scheduled on a day past the
+// end of their stay. Moving between two stays on the same day is not an
+// overlap. Warnings are days of the trip no stay covers and days on which no
+// covering stay has any activity.
+func CheckItineraryDates(startDate, endDate string, stays []Stay) (errs, warnings []Issue) {
+	tripStart, err1 := ParseDate(startDate)
+	tripEnd, err2 := ParseDate(endDate)
+	if err1 != nil || err2 != nil {
+		errs = append(errs, Issue{Code: IssueInvalidDate, Message: "trip dates must be in YYYY-MM-DD format"})
+		return errs, nil
+	}
+	if tripEnd.Before(tripStart) {
+		errs = append(errs, Issue{
+			Code:      IssueInvertedRange,
+			Message:   "trip ends before it starts",
+			StartDate: FormatDate(tripStart),
+			EndDate:   FormatDate(tripEnd),
+		})
+		return errs, nil
+	}
+
+	type span struct {
+		stay       Stay
+		start, end time.Time
+	}
+	var spans []span
+	for _, stay := range stays {
+		start, err1 := ParseDate(stay.StartDate)
+		end, err2 := ParseDate(stay.EndDate)
+		if err1 != nil || err2 != nil {
+			errs = append(errs, Issue{
+				Code:        IssueInvalidDate,
+				Message:     "destination dates must be in YYYY-MM-DD format",
+				Destination: stay.Name,
+			})
+			continue
+		}
+		if end.Before(start) {
+			errs = append(errs, Issue{
+				Code:        IssueInvertedRange,
+				Message:     "destination ends before it starts",
+				Destination: stay.Name,
+				StartDate:   FormatDate(start),
+				EndDate:     FormatDate(end),
+			})
+			continue
+		}
+		length := int(end.Sub(start)/day) + 1
+		for _, d := range stay.ActivityDays {
+			if d > length {
+				errs = append(errs, Issue{
+					Code:        IssueActivityDay,
+					Message:     fmt.Sprintf("activity on day %d of a %d-day stay", d, length),
+					Destination: stay.Name,
+				})
+			}
+		}
+		if start.Before(tripStart) || end.After(tripEnd) {
+			errs = append(errs, Issue{
+				Code:        IssueOutOfRange,
+				Message:     "destination lies outside the trip dates",
+				Destination: stay.Name,
+				StartDate:   FormatDate(start),
+				EndDate:     FormatDate(end),
+			})
+		}
+		spans = append(spans, span{stay: stay, start: start, end: end})
+	}
+
+	sort.SliceStable(spans, func(i, j int) bool { return spans[i].start.Before(spans[j].start) })
+	for i := 1; i < len(spans); i++ {
+		// Compare against the earlier stay that ends last, so a long stay
+		// is reported against every stay it swallows.
+		latest := spans[0]
+		for _, s := range spans[1:i] {
+			if s.end.After(latest.end) {
+				latest = s
+			}
+		}
+		cur := spans[i]
+		if cur.start.Before(latest.end) {
+			errs = append(errs, Issue{
+				Code:        IssueOverlap,
+				Message:     fmt.Sprintf("destination overlaps %s", latest.stay.Name),
+				Destination: cur.stay.Name,
+				StartDate:   FormatDate(cur.start),
+				EndDate:     FormatDate(minTime(latest.end, cur.end)),
+			})
+		}
+	}
+
+	covered := make(map[time.Time]bool)
+	active := make(map[time.Time]bool)
+	for _, s := range spans {
+		for d := s.start; !d.After(s.end); d = d.Add(day) {
+			covered[d] = true
+			if s.stay.Activities > 0 {
+				active[d] = true
+			}
+		}
+		for _, i := range s.stay.ActivityDays {
+			active[s.start.Add(time.Duration(i-1)*day)] = true
+		}
+	}
+
+	warnings = append(warnings, dayRuns(tripStart, tripEnd, func(d time.Time) bool { return !covered[d] },
+		IssueGap, "no destination covers these days")...)
+	warnings = append(warnings, dayRuns(tripStart, tripEnd, func(d time.Time) bool { return covered[d] && !active[d] },
+		IssueNoActivities, "no activities are planned on these days")...)
+
+	return errs, warnings
+}
+
+// dayRuns returns one issue per run of consecutive days between start and
+// end for which match is true.
+func dayRuns(start, end time.Time, match func(time.Time) bool, code, message string) []Issue {
+	var (
+		issues   []Issue
+		runStart time.Time
+		inRun    bool
+	)
+	for d := start; !d.After(end.Add(day)); d = d.Add(day) {
+		if !d.After(end) && match(d) {
+			if !inRun {
+				runStart, inRun = d, true
+			}
+			continue
+		}
+		if inRun {
+			issues = append(issues, Issue{
+				Code:      code,
+				Message:   message,
+				StartDate: FormatDate(runStart),
+				EndDate:   FormatDate(d.Add(-day)),
+			})
+			inRun = false
+		}
+	}
+	return issues
+}
+
+// ParseDate parses a calendar date in YYYY-MM-DD form. RFC 3339 timestamps,
+// as returned for DATE columns by the Postgres driver, are also accepted and
+// truncated to their date.
+func ParseDate(s string) (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, s); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), nil
+}
+
+// FormatDate formats t as YYYY-MM-DD.
+func FormatDate(t time.Time) string {
+	return t.Format(time.DateOnly)
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+// ParseClock parses a time of day in HH:MM form and returns the number of
+// minutes since midnight.
+func ParseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("time of day %q must be in HH:MM format", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// FormatClock formats minutes since midnight as HH:MM, wrapping past midnight.
+func FormatClock(minutes int) string {
+	minutes %= 24 * 60
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
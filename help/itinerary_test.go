@@ -0,0 +1,82 @@
+package help
+
+import "testing"
+
+func codes(issues []Issue) []string {
+	var out []string
+	for _, i := range issues {
+		out = append(out, i.Code+":"+i.Destination+":"+i.StartDate+".."+i.EndDate)
+	}
+	return out
+}
+
+func TestCheckItineraryDatesConsistent(t *testing.T) {
+	errs, warnings := CheckItineraryDates("2024-08-01", "2024-08-05", []Stay{
+		{Name: "Tashkent", StartDate: "2024-08-01", EndDate: "2024-08-03", Activities: 2},
+		{Name: "Samarkand", StartDate: "2024-08-03T00:00:00Z", EndDate: "2024-08-05", Activities: 1},
+	})
+	if len(errs) != 0 || len(warnings) != 0 {
+		t.Errorf("got errors %v and warnings %v, want none", codes(errs), codes(warnings))
+	}
+}
+
+func TestCheckItineraryDatesProblems(t *testing.T) {
+	errs, warnings := CheckItineraryDates("2024-08-01", "2024-08-10", []Stay{
+		{Name: "Tashkent", StartDate: "2024-08-01", EndDate: "2024-08-04", Activities: 1},
+		{Name: "Samarkand", StartDate: "2024-08-03", EndDate: "2024-08-05", Activities: 1},
+		{Name: "Bukhara", StartDate: "2024-08-07", EndDate: "2024-08-08"},
+		{Name: "Khiva", StartDate: "2024-08-09", EndDate: "2024-08-12", Activities: 1},
+		{Name: "Nukus", StartDate: "2024-08-10", EndDate: "2024-08-09"},
+		{Name: "Termez", StartDate: "next week", EndDate: "2024-08-09"},
+	})
+
+	wantErrs := []string{
+		"out_of_range:Khiva:2024-08-09..2024-08-12",
+		"inverted_range:Nukus:2024-08-10..2024-08-09",
+		"invalid_date:Termez:..",
+		"overlap:Samarkand:2024-08-03..2024-08-04",
+	}
+	wantWarnings := []string{
+		"gap::2024-08-06..2024-08-06",
+		"no_activities::2024-08-07..2024-08-08",
+	}
+
+	if got := codes(errs); !equal(got, wantErrs) {
+		t.Errorf("errors = %v, want %v", got, wantErrs)
+	}
+	if got := codes(warnings); !equal(got, wantWarnings) {
+		t.Errorf("warnings = %v, want %v", got, wantWarnings)
+	}
+}
+
+func TestCheckItineraryDatesActivityDays(t *testing.T) {
+	errs, warnings := CheckItineraryDates("2024-08-01", "2024-08-03", []Stay{
+		{Name: "Tashkent", StartDate: "2024-08-01", EndDate: "2024-08-03", ActivityDays: []int{1, 3, 5}},
+	})
+
+	if got, want := codes(errs), []string{"activity_day_out_of_range:Tashkent:.."}; !equal(got, want) {
+		t.Errorf("errors = %v, want %v", got, want)
+	}
+	if got, want := codes(warnings), []string{"no_activities::2024-08-02..2024-08-02"}; !equal(got, want) {
+		t.Errorf("warnings = %v, want %v", got, want)
+	}
+}
+
+func TestCheckItineraryDatesInvertedTrip(t *testing.T) {
+	errs, _ := CheckItineraryDates("2024-08-10", "2024-08-01", nil)
+	if len(errs) != 1 || errs[0].Code != IssueInvertedRange {
+		t.Errorf("errors = %v, want a single inverted_range", codes(errs))
+	}
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,41 @@
+package help
+
+import (
+	"regexp"
+	"strings"
+)
+
+// A hashtag or mention must start the text or follow a character that
+// cannot be part of a word, a URL path or an HTML entity, so that
+// "page/#top", "&#39;" and "mail@example.com" are not picked up.
+var (
+	hashtagRe = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_&/#@])#(\p{L}[\p{L}\p{N}_]{0,49})`)
+	mentionRe = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_/#@.])@([A-Za-z0-9_][A-Za-z0-9_.]{0,49})`)
+)
+
+// ExtractHashtags returns the distinct #hashtags in text, lowercased and
+// without the '#', in order of first appearance.
+func ExtractHashtags(text string) []string {
+	return extract(hashtagRe, text)
+}
+
+// ExtractMentions returns the distinct @usernames in text, lowercased and
+// without the '@', in order of first appearance.
+func ExtractMentions(text string) []string {
+	return extract(mentionRe, text)
+}
+
+func extract(re *regexp.Regexp, text string) []string {
+	var found []string
+	seen := make(map[string]bool)
+	for _, m := range re.FindAllStringSubmatch(text, -1) {
+		// A trailing dot ends the sentence rather than the name.
+		name := strings.ToLower(strings.TrimRight(m[1], "."))
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		found = append(found, name)
+	}
+	return found
+}
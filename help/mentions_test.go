@@ -0,0 +1,41 @@
+package help
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractHashtags(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"#Hiking in the #alps, more #hiking!", []string{"hiking", "alps"}},
+		{"# Heading\n#2024 trip", nil},
+		{"see https://example.com/page/#top and &#39;", nil},
+		{"(#café) #road_trip", []string{"café", "road_trip"}},
+		{"no#tag here", nil},
+	}
+	for _, tt := range tests {
+		if got := ExtractHashtags(tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ExtractHashtags(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestExtractMentions(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"Thanks @Anna and @bob_99. See you @anna", []string{"anna", "bob_99"}},
+		{"mail me at anna@example.com", nil},
+		{"with @j.doe!", []string{"j.doe"}},
+		{"@", nil},
+	}
+	for _, tt := range tests {
+		if got := ExtractMentions(tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ExtractMentions(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package help
+
+import "testing"
+
+func TestShareToken(t *testing.T) {
+	token := SignShareToken("secret", "link1", "story", "story1", 1700000000)
+
+	linkID, err := ShareTokenLinkID(token)
+	if err != nil || linkID != "link1" {
+		t.Fatalf("ShareTokenLinkID = %q, %v, want link1", linkID, err)
+	}
+	if !VerifyShareToken("secret", token, "link1", "story", "story1", 1700000000) {
+		t.Error("token did not verify against the link it was signed for")
+	}
+
+	for name, ok := range map[string]bool{
+		"other secret": VerifyShareToken("other", token, "link1", "story", "story1", 1700000000),
+		"other item":   VerifyShareToken("secret", token, "link1", "story", "story2", 1700000000),
+		"other type":   VerifyShareToken("secret", token, "link1", "itinerary", "story1", 1700000000),
+		"other expiry": VerifyShareToken("secret", token, "link1", "story", "story1", 0),
+	} {
+		if ok {
+			t.Errorf("token verified with %s", name)
+		}
+	}
+
+	if _, err := ShareTokenLinkID("garbage"); err != ErrInvalidShareToken {
+		t.Errorf("ShareTokenLinkID(garbage) error = %v, want %v", err, ErrInvalidShareToken)
+	}
+}
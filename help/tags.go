@@ -0,0 +1,44 @@
+package help
+
+import (
+	"strings"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// MaxTagLength is the longest tag, in runes, that story_tags can hold.
+const MaxTagLength = 50
+
+var tagFolder = cases.Fold()
+
+// NormalizeTag returns the canonical form of a tag so that "Paris",
+// "paris " and "#paris" are the same tag: leading '#' signs are dropped,
+// the text is case folded between two NFC passes, so that the result does
+// not depend on the normalisation form of the input, and runs of whitespace
+// become a single space. It returns "" for a tag with no content.
+func NormalizeTag(tag string) string {
+	tag = strings.TrimLeft(strings.TrimSpace(tag), "#")
+	tag = norm.NFC.String(tagFolder.String(norm.NFC.String(tag)))
+	tag = strings.Join(strings.Fields(tag), " ")
+	if runes := []rune(tag); len(runes) > MaxTagLength {
+		tag = strings.TrimSpace(string(runes[:MaxTagLength]))
+	}
+	return tag
+}
+
+// NormalizeTags normalizes tags and drops blanks and duplicates, keeping
+// the order of first appearance.
+func NormalizeTags(tags []string) []string {
+	var normalized []string
+	seen := make(map[string]bool)
+	for _, tag := range tags {
+		tag = NormalizeTag(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
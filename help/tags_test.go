@@ -0,0 +1,35 @@
+package help
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeTag(t *testing.T) {
+	tests := map[string]string{
+		"Paris":            "paris",
+		"paris ":           "paris",
+		"#paris":           "paris",
+		"  ##New   York\t": "new york",
+		"Café":            "café",
+		"STRASSE":          "strasse",
+		"Straße":           "strasse",
+		"#":                "",
+		"   ":              "",
+		"ΣΊΣΥΦΟΣ":          "σίσυφοσ",
+		"abcdefghijklmnopqrstuvwxyzabcdefghijklmnopqrstuvwxyz": "abcdefghijklmnopqrstuvwxyzabcdefghijklmnopqrstuvwx",
+	}
+	for in, want := range tests {
+		if got := NormalizeTag(in); got != want {
+			t.Errorf("NormalizeTag(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestNormalizeTags(t *testing.T) {
+	got := NormalizeTags([]string{"Paris", "paris ", "#paris", "", "Lyon"})
+	want := []string{"paris", "lyon"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NormalizeTags = %q, want %q", got, want)
+	}
+}
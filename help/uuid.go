@@ -0,0 +1,11 @@
+package help
+
+import "regexp"
+
+var uuidPattern = regexp.MustCompile(`(?i)^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+
+// IsUUID reports whether s is a UUID in its canonical textual form, so that
+// client-supplied ids can be rejected before Postgres fails to cast them.
+func IsUUID(s string) bool {
+	return uuidPattern.MatchString(s)
+}
@@ -0,0 +1,308 @@
+package markdown
+
+import (
+	"html"
+	"strings"
+)
+
+// renderInline renders inline Markdown, escaping everything that is not
+// recognised syntax.
+func renderInline(s string) string {
+	var b, text strings.Builder
+	flush := func() {
+		b.WriteString(html.EscapeString(text.String()))
+		text.Reset()
+	}
+
+	for i := 0; i < len(s); {
+		switch s[i] {
+		case '\\':
+			if i+1 < len(s) && s[i+1] == '\n' {
+				flush()
+				b.WriteString("<br>\n")
+				i += 2
+				continue
+			}
+			if i+1 < len(s) && isPunct(s[i+1]) {
+				text.WriteByte(s[i+1])
+				i += 2
+				continue
+			}
+
+		case '\n':
+			if pending := text.String(); strings.HasSuffix(pending, "  ") {
+				text.Reset()
+				text.WriteString(strings.TrimRight(pending, " "))
+				flush()
+				b.WriteString("<br>\n")
+				i++
+				continue
+			}
+
+		case '`':
+			n := runLength(s, i)
+			if j := findRun(s, i+n, '`', n); j >= 0 {
+				flush()
+				b.WriteString("<code>" + html.EscapeString(codeSpan(s[i+n:j])) + "</code>")
+				i = j + n
+				continue
+			}
+			text.WriteString(s[i : i+n])
+			i += n
+			continue
+
+		case '*', '_', '~':
+			if end, inner, open, close, ok := emphasis(s, i); ok {
+				flush()
+				b.WriteString(open + renderInline(inner) + close)
+				i = end
+				continue
+			}
+			n := runLength(s, i)
+			text.WriteString(s[i : i+n])
+			i += n
+			continue
+
+		case '!':
+			if i+1 < len(s) && s[i+1] == '[' {
+				if label, url, end, ok := linkAt(s, i+1); ok {
+					flush()
+					if safeURL(url, false) {
+						b.WriteString(`<img src="` + html.EscapeString(url) + `" alt="` + html.EscapeString(label) + `">`)
+					} else {
+						b.WriteString(html.EscapeString(label))
+					}
+					i = end
+					continue
+				}
+			}
+
+		case '[':
+			if label, url, end, ok := linkAt(s, i); ok {
+				flush()
+				if safeURL(url, true) {
+					b.WriteString(link(url, renderInline(label)))
+				} else {
+					b.WriteString(renderInline(label))
+				}
+				i = end
+				continue
+			}
+
+		case '<':
+			if j := strings.IndexByte(s[i:], '>'); j > 0 {
+				url := s[i+1 : i+j]
+				if isAutolink(url) {
+					flush()
+					b.WriteString(link(url, html.EscapeString(url)))
+					i += j + 1
+					continue
+				}
+			}
+		}
+
+		text.WriteByte(s[i])
+		i++
+	}
+
+	flush()
+	return b.String()
+}
+
+func link(url, label string) string {
+	return `<a href="` + html.EscapeString(url) + `" rel="nofollow noopener noreferrer">` + label + `</a>`
+}
+
+func isPunct(c byte) bool {
+	return strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", c) >= 0
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\n' || c == '\t'
+}
+
+func isAlnum(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// runLength returns how many times s[i] repeats from i.
+func runLength(s string, i int) int {
+	n := 1
+	for i+n < len(s) && s[i+n] == s[i] {
+		n++
+	}
+	return n
+}
+
+// findRun returns the index at or after from of a run of exactly n c's, or
+// -1.
+func findRun(s string, from int, c byte, n int) int {
+	for j := from; j < len(s); {
+		if s[j] != c {
+			j++
+			continue
+		}
+		run := runLength(s, j)
+		if run == n {
+			return j
+		}
+		j += run
+	}
+	return -1
+}
+
+// codeSpan normalises the content of a code span: line breaks become spaces
+// and one space of padding on both sides is removed.
+func codeSpan(code string) string {
+	code = strings.ReplaceAll(code, "\n", " ")
+	if len(code) >= 2 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.Trim(code, " ") != "" {
+		code = code[1 : len(code)-1]
+	}
+	return code
+}
+
+// emphasis parses emphasis, strong emphasis or strikethrough opening at
+// s[i]. It returns the end of the span, its content and the tags to wrap
+// the content in.
+func emphasis(s string, i int) (end int, inner, open, close string, ok bool) {
+	c := s[i]
+	n := runLength(s, i)
+	switch {
+	case c == '~' && n == 2:
+		open, close = "<del>", "</del>"
+	case c == '~':
+		return 0, "", "", "", false
+	case n >= 3:
+		n = 3
+		open, close = "<strong><em>", "</em></strong>"
+	case n == 2:
+		open, close = "<strong>", "</strong>"
+	default:
+		open, close = "<em>", "</em>"
+	}
+
+	start := i + n
+	if start >= len(s) || isSpace(s[start]) {
+		return 0, "", "", "", false
+	}
+	// Underscores inside words, as in snake_case, are not emphasis.
+	if c == '_' && i > 0 && isAlnum(s[i-1]) {
+		return 0, "", "", "", false
+	}
+
+	for j := start + 1; j+n <= len(s); j++ {
+		if s[j] != c {
+			continue
+		}
+		run := runLength(s, j)
+		if run != n || isSpace(s[j-1]) || c == '_' && j+n < len(s) && isAlnum(s[j+n]) {
+			j += run - 1
+			continue
+		}
+		return j + n, s[start:j], open, close, true
+	}
+	return 0, "", "", "", false
+}
+
+// linkAt parses a link or image body [label](url "title") starting at the
+// bracket s[i].
+func linkAt(s string, i int) (label, url string, end int, ok bool) {
+	depth := 0
+	k := -1
+	for j := i; j < len(s) && k < 0; j++ {
+		switch s[j] {
+		case '\\':
+			j++
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				k = j
+			}
+		}
+	}
+	if k < 0 || k+1 >= len(s) || s[k+1] != '(' {
+		return "", "", 0, false
+	}
+	label = s[i+1 : k]
+
+	j := k + 2
+	for j < len(s) && s[j] == ' ' {
+		j++
+	}
+	if j < len(s) && s[j] == '<' {
+		close := strings.IndexByte(s[j:], '>')
+		if close < 0 {
+			return "", "", 0, false
+		}
+		url = s[j+1 : j+close]
+		j += close + 1
+	} else {
+		start, parens := j, 0
+		for ; j < len(s) && !isSpace(s[j]); j++ {
+			if s[j] == '(' {
+				parens++
+			} else if s[j] == ')' {
+				if parens == 0 {
+					break
+				}
+				parens--
+			}
+		}
+		url = s[start:j]
+	}
+
+	for j < len(s) && isSpace(s[j]) {
+		j++
+	}
+	if j < len(s) && (s[j] == '"' || s[j] == '\'') {
+		close := strings.IndexByte(s[j+1:], s[j])
+		if close < 0 {
+			return "", "", 0, false
+		}
+		j += close + 2
+		for j < len(s) && isSpace(s[j]) {
+			j++
+		}
+	}
+	if j >= len(s) || s[j] != ')' {
+		return "", "", 0, false
+	}
+
+	return label, url, j + 1, true
+}
+
+// safeURL reports whether url is relative or uses an allowed scheme: http
+// and https, plus mailto for links.
+func safeURL(url string, allowMailto bool) bool {
+	if url == "" {
+		return false
+	}
+	// Browsers ignore control characters and spaces inside a scheme, so
+	// reject them outright rather than risk misreading one.
+	for i := 0; i < len(url); i++ {
+		if url[i] <= ' ' || url[i] == 0x7f {
+			return false
+		}
+	}
+	colon := strings.IndexByte(url, ':')
+	if colon < 0 || strings.ContainsAny(url[:colon], "/?#") {
+		return true
+	}
+	switch strings.ToLower(url[:colon]) {
+	case "http", "https":
+		return true
+	case "mailto":
+		return allowMailto
+	}
+	return false
+}
+
+func isAutolink(url string) bool {
+	lower := strings.ToLower(url)
+	if !strings.HasPrefix(lower, "http://") && !strings.HasPrefix(lower, "https://") && !strings.HasPrefix(lower, "mailto:") {
+		return false
+	}
+	return !strings.ContainsAny(url, " \t\n<>")
+}
@@ -0,0 +1,231 @@
+// Package markdown renders a commonly used subset of Markdown to HTML that
+// is safe to embed in a page. Raw HTML in the source is always escaped, the
+// output only contains allow-listed tags and links are limited to safe URL
+// schemes, so no sanitizing pass over the result is needed.
+//
+// Supported: ATX headings, paragraphs with hard line breaks, emphasis,
+// strong, strikethrough, inline code, fenced code blocks, block quotes,
+// ordered and unordered lists, thematic breaks, links, images and
+// autolinks.
+package markdown
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	headingRe   = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	fenceRe     = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})")
+	ruleRe      = regexp.MustCompile(`^ {0,3}(?:(?:-[ \t]*){3,}|(?:\*[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	quoteRe     = regexp.MustCompile(`^ {0,3}> ?`)
+	bulletRe    = regexp.MustCompile(`^( {0,3})([-*+])[ \t]+`)
+	orderedRe   = regexp.MustCompile(`^( {0,3})(\d{1,9})[.)][ \t]+`)
+	blankLineRe = regexp.MustCompile(`^[ \t]*$`)
+)
+
+// HTML renders Markdown source to sanitized HTML.
+func HTML(src string) string {
+	var b strings.Builder
+	renderBlocks(&b, splitLines(src), false)
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// PlainHTML renders plain text to sanitized HTML: blank lines separate
+// paragraphs and single line breaks are kept.
+func PlainHTML(src string) string {
+	var b strings.Builder
+	for _, para := range strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n\n") {
+		para = strings.Trim(para, "\n")
+		if strings.TrimSpace(para) == "" {
+			continue
+		}
+		b.WriteString("<p>")
+		b.WriteString(strings.ReplaceAll(html.EscapeString(para), "\n", "<br>\n"))
+		b.WriteString("</p>\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func splitLines(src string) []string {
+	src = strings.ReplaceAll(src, "\r\n", "\n")
+	src = strings.ReplaceAll(src, "\t", "    ")
+	return strings.Split(src, "\n")
+}
+
+func isBlank(line string) bool {
+	return blankLineRe.MatchString(line)
+}
+
+// startsBlock reports whether line begins a block that interrupts a
+// paragraph.
+func startsBlock(line string) bool {
+	return headingRe.MatchString(line) || fenceRe.MatchString(line) || ruleRe.MatchString(line) ||
+		quoteRe.MatchString(line) || bulletRe.MatchString(line) || orderedRe.MatchString(line)
+}
+
+// renderBlocks renders lines as a sequence of blocks. In tight mode, used
+// for list items without blank lines, paragraphs are written without <p>.
+func renderBlocks(b *strings.Builder, lines []string, tight bool) {
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		switch {
+		case isBlank(line):
+			i++
+
+		case fenceRe.MatchString(line):
+			i = renderFence(b, lines, i)
+
+		case headingRe.MatchString(line):
+			m := headingRe.FindStringSubmatch(line)
+			level := strconv.Itoa(len(m[1]))
+			b.WriteString("<h" + level + ">" + renderInline(m[2]) + "</h" + level + ">\n")
+			i++
+
+		case ruleRe.MatchString(line):
+			b.WriteString("<hr>\n")
+			i++
+
+		case quoteRe.MatchString(line):
+			var inner []string
+			for i < len(lines) && quoteRe.MatchString(lines[i]) {
+				inner = append(inner, quoteRe.ReplaceAllString(lines[i], ""))
+				i++
+			}
+			b.WriteString("<blockquote>\n")
+			renderBlocks(b, inner, false)
+			b.WriteString("</blockquote>\n")
+
+		case bulletRe.MatchString(line) || orderedRe.MatchString(line):
+			i = renderList(b, lines, i)
+
+		default:
+			var para []string
+			for i < len(lines) && !isBlank(lines[i]) && (len(para) == 0 || !startsBlock(lines[i])) {
+				para = append(para, lines[i])
+				i++
+			}
+			text := renderParagraph(para)
+			if tight {
+				b.WriteString(text + "\n")
+			} else {
+				b.WriteString("<p>" + text + "</p>\n")
+			}
+		}
+	}
+}
+
+// renderFence renders the fenced code block starting at lines[start] and
+// returns the index of the first line after it. An unclosed fence runs to
+// the end of the input.
+func renderFence(b *strings.Builder, lines []string, start int) int {
+	fence := fenceRe.FindStringSubmatch(lines[start])[1]
+	i := start + 1
+	var code []string
+	for ; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" {
+			i++
+			break
+		}
+		code = append(code, lines[i])
+	}
+
+	b.WriteString("<pre><code>")
+	if len(code) > 0 {
+		b.WriteString(html.EscapeString(strings.Join(code, "\n")) + "\n")
+	}
+	b.WriteString("</code></pre>\n")
+	return i
+}
+
+// renderList renders the list starting at lines[start] and returns the
+// index of the first line after it. Lines indented past the marker belong
+// to the current item, which allows nested lists.
+func renderList(b *strings.Builder, lines []string, start int) int {
+	ordered := !bulletRe.MatchString(lines[start])
+	marker := bulletRe
+	if ordered {
+		marker = orderedRe
+	}
+
+	tag := "ul"
+	if ordered {
+		tag = "ol"
+		if n := orderedRe.FindStringSubmatch(lines[start])[2]; strings.TrimLeft(n, "0") != "1" {
+			num, _ := strconv.Atoi(n)
+			b.WriteString(`<ol start="` + strconv.Itoa(num) + `">` + "\n")
+		} else {
+			b.WriteString("<ol>\n")
+		}
+	} else {
+		b.WriteString("<ul>\n")
+	}
+
+	var items [][]string
+	tight := true
+	i := start
+	for i < len(lines) {
+		m := marker.FindStringSubmatch(lines[i])
+		if m == nil {
+			break
+		}
+		indent := len(marker.FindString(lines[i]))
+		item := []string{lines[i][indent:]}
+		i++
+		for i < len(lines) {
+			line := lines[i]
+			if isBlank(line) {
+				// A blank line continues the item only when indented
+				// content follows it.
+				if i+1 < len(lines) && leadingSpaces(lines[i+1]) >= indent {
+					item = append(item, "")
+					tight = false
+					i++
+					continue
+				}
+				break
+			}
+			if leadingSpaces(line) >= indent {
+				item = append(item, line[indent:])
+			} else if !startsBlock(line) && !isBlank(item[len(item)-1]) {
+				// Lazy continuation of the item's paragraph.
+				item = append(item, strings.TrimLeft(line, " "))
+			} else {
+				break
+			}
+			i++
+		}
+		items = append(items, item)
+
+		if i+1 < len(lines) && isBlank(lines[i]) && marker.MatchString(lines[i+1]) {
+			tight = false
+			i++
+		}
+	}
+
+	for _, item := range items {
+		var content strings.Builder
+		renderBlocks(&content, item, tight)
+		b.WriteString("<li>" + strings.TrimSuffix(content.String(), "\n") + "</li>\n")
+	}
+	b.WriteString("</" + tag + ">\n")
+	return i
+}
+
+func leadingSpaces(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// renderParagraph renders the lines of a paragraph as one run of inline
+// content, so emphasis and links may span lines.
+func renderParagraph(lines []string) string {
+	trimmed := make([]string, len(lines))
+	for i, line := range lines {
+		trimmed[i] = strings.TrimLeft(line, " ")
+	}
+	trimmed[len(trimmed)-1] = strings.TrimRight(trimmed[len(trimmed)-1], " ")
+	return renderInline(strings.Join(trimmed, "\n"))
+}
@@ -0,0 +1,74 @@
+package markdown
+
+import "testing"
+
+func TestHTML(t *testing.T) {
+	tests := []struct {
+		name, src, want string
+	}{
+		{"paragraphs", "first\nline\n\nsecond", "<p>first\nline</p>\n<p>second</p>"},
+		{"hard break", "first  \nsecond", "<p>first<br>\nsecond</p>"},
+		{"heading", "## Day *one* ##", "<h2>Day <em>one</em></h2>"},
+		{"hashtag is not a heading", "#travel", "<p>#travel</p>"},
+		{"emphasis", "**bold** and _it_ and ~~gone~~", "<p><strong>bold</strong> and <em>it</em> and <del>gone</del></p>"},
+		{"nested emphasis", "*a **b** c*", "<p><em>a <strong>b</strong> c</em></p>"},
+		{"intraword underscore", "snake_case_name", "<p>snake_case_name</p>"},
+		{"lone asterisk", "2 * 3 * 4", "<p>2 * 3 * 4</p>"},
+		{"code span", "run `a <b>` now", "<p>run <code>a &lt;b&gt;</code> now</p>"},
+		{"fence", "```go\nif a < b {\n```", "<pre><code>if a &lt; b {\n</code></pre>"},
+		{"quote", "> quoted\n> **text**", "<blockquote>\n<p>quoted\n<strong>text</strong></p>\n</blockquote>"},
+		{"rule", "a\n\n---\n\nb", "<p>a</p>\n<hr>\n<p>b</p>"},
+		{"bullets", "- one\n- two", "<ul>\n<li>one</li>\n<li>two</li>\n</ul>"},
+		{"ordered start", "3. three\n4. four", "<ol start=\"3\">\n<li>three</li>\n<li>four</li>\n</ol>"},
+		{"nested list", "- one\n  - inner\n- two", "<ul>\n<li>one\n<ul>\n<li>inner</li>\n</ul></li>\n<li>two</li>\n</ul>"},
+		{"loose list", "- one\n\n- two", "<ul>\n<li><p>one</p></li>\n<li><p>two</p></li>\n</ul>"},
+		{"link", "[site](https://example.com \"t\")", `<p><a href="https://example.com" rel="nofollow noopener noreferrer">site</a></p>`},
+		{"relative link", "[map](/maps?a=1&b=2)", `<p><a href="/maps?a=1&amp;b=2" rel="nofollow noopener noreferrer">map</a></p>`},
+		{"autolink", "<https://example.com>", `<p><a href="https://example.com" rel="nofollow noopener noreferrer">https://example.com</a></p>`},
+		{"image", "![a \"view\"](https://img.example/v.jpg)", `<p><img src="https://img.example/v.jpg" alt="a &#34;view&#34;"></p>`},
+		{"escape", `\*not em\*`, "<p>*not em*</p>"},
+	}
+	for _, tt := range tests {
+		if got := HTML(tt.src); got != tt.want {
+			t.Errorf("%s: HTML(%q) =\n%q\nwant\n%q", tt.name, tt.src, got, tt.want)
+		}
+	}
+}
+
+func TestHTMLIsSafe(t *testing.T) {
+	tests := []struct {
+		src, want string
+	}{
+		{"<script>alert(1)</script>", "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>"},
+		{"<img src=x onerror=alert(1)>", "<p>&lt;img src=x onerror=alert(1)&gt;</p>"},
+		{"[x](javascript:alert(1))", "<p>x</p>"},
+		{"[x](JavaScript:alert(1))", "<p>x</p>"},
+		{"[x](data:text/html;base64,AAAA)", "<p>x</p>"},
+		{"![x](javascript:alert(1))", "<p>x</p>"},
+		{"![x](mailto:a@b.c)", "<p>x</p>"},
+		{"[x](<java\tscript:alert(1)>)", "<p>x</p>"},
+		{`[x](https://a.example/"onmouseover="alert(1))`, `<p><a href="https://a.example/&#34;onmouseover=&#34;alert(1)" rel="nofollow noopener noreferrer">x</a></p>`},
+		{"<javascript:alert(1)>", "<p>&lt;javascript:alert(1)&gt;</p>"},
+	}
+	for _, tt := range tests {
+		if got := HTML(tt.src); got != tt.want {
+			t.Errorf("HTML(%q) = %q, want %q", tt.src, got, tt.want)
+		}
+	}
+}
+
+func TestPlainHTML(t *testing.T) {
+	got := PlainHTML("a <b>\nline\n\n\n**not markdown**")
+	want := "<p>a &lt;b&gt;<br>\nline</p>\n<p>**not markdown**</p>"
+	if got != want {
+		t.Errorf("PlainHTML = %q, want %q", got, want)
+	}
+}
+
+func TestText(t *testing.T) {
+	got := Text("# Title\n\nSome **bold** [link](https://x.example) &amp; `code`\n\n- one\n- two")
+	want := "Title\nSome bold link &amp; code\none\ntwo"
+	if got != want {
+		t.Errorf("Text = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,53 @@
+package markdown
+
+import (
+	"html"
+	"strings"
+)
+
+// blockTags are the tags after which Text starts a new line.
+var blockTags = map[string]bool{
+	"p": true, "br": true, "hr": true, "li": true, "pre": true, "blockquote": true, "ul": true, "ol": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+}
+
+// Text returns the readable text of Markdown source without its syntax,
+// one block per line.
+func Text(src string) string {
+	return htmlText(HTML(src))
+}
+
+// htmlText strips the tags from HTML produced by this package and decodes
+// its entities. Attribute values never contain a raw '>' because they are
+// escaped when written.
+func htmlText(s string) string {
+	var b strings.Builder
+	for {
+		i := strings.IndexByte(s, '<')
+		if i < 0 {
+			b.WriteString(s)
+			break
+		}
+		b.WriteString(s[:i])
+		j := strings.IndexByte(s[i:], '>')
+		if j < 0 {
+			break
+		}
+		name := strings.TrimPrefix(s[i+1:i+j], "/")
+		if k := strings.IndexByte(name, ' '); k >= 0 {
+			name = name[:k]
+		}
+		if blockTags[name] {
+			b.WriteByte('\n')
+		}
+		s = s[i+j+1:]
+	}
+
+	var lines []string
+	for _, line := range strings.Split(html.UnescapeString(b.String()), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,247 @@
+// Package media validates uploaded images, strips their embedded metadata
+// and generates thumbnails using only the standard library.
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+)
+
+const (
+	TypeJPEG = "image/jpeg"
+	TypePNG  = "image/png"
+	TypeGIF  = "image/gif"
+)
+
+// maxPixels bounds the decoded size of an image so that a small, highly
+// compressed upload cannot exhaust memory.
+const maxPixels = 50_000_000
+
+var (
+	ErrUnsupportedType = errors.New("unsupported media type")
+	ErrMalformed       = errors.New("malformed image")
+	ErrTooLarge        = errors.New("image dimensions are too large")
+)
+
+// Sniff detects the content type of data from its leading bytes and returns
+// an error unless it is one of the accepted image types.
+func Sniff(data []byte) (string, error) {
+	contentType := http.DetectContentType(data)
+	switch contentType {
+	case TypeJPEG, TypePNG, TypeGIF:
+		return contentType, nil
+	}
+	return "", fmt.Errorf("%w: %s", ErrUnsupportedType, contentType)
+}
+
+// Extension returns the file extension for an accepted content type.
+func Extension(contentType string) string {
+	switch contentType {
+	case TypeJPEG:
+		return ".jpg"
+	case TypePNG:
+		return ".png"
+	case TypeGIF:
+		return ".gif"
+	}
+	return ""
+}
+
+// Dimensions returns the width and height of an image without decoding its
+// pixels.
+func Dimensions(data []byte) (int, int, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+	if cfg.Width*cfg.Height > maxPixels {
+		return 0, 0, ErrTooLarge
+	}
+	return cfg.Width, cfg.Height, nil
+}
+
+// StripMetadata removes EXIF, XMP, IPTC and comment data, which can reveal
+// where and when a photo was taken, without re-encoding the image. Types
+// that carry no such metadata are returned unchanged.
+func StripMetadata(contentType string, data []byte) ([]byte, error) {
+	switch contentType {
+	case TypeJPEG:
+		return stripJPEG(data)
+	case TypePNG:
+		return stripPNG(data)
+	}
+	return data, nil
+}
+
+// stripJPEG drops APP1 (EXIF, XMP), APP13 (IPTC) and COM segments.
+func stripJPEG(data []byte) ([]byte, error) {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return nil, ErrMalformed
+	}
+
+	out := append(make([]byte, 0, len(data)), data[:2]...)
+	i := 2
+	for i < len(data) {
+		if data[i] != 0xFF {
+			return nil, ErrMalformed
+		}
+		// Markers may be preceded by any number of 0xFF fill bytes.
+		start := i
+		for i < len(data) && data[i] == 0xFF {
+			i++
+		}
+		if i >= len(data) {
+			return nil, ErrMalformed
+		}
+		marker := data[i]
+		i++
+
+		switch {
+		case marker == 0xDA:
+			// Start of scan: the entropy-coded data and everything after
+			// it are copied as they are.
+			return append(out, data[start:]...), nil
+		case marker == 0x01 || (marker >= 0xD0 && marker <= 0xD9):
+			out = append(out, data[start:i]...)
+			if marker == 0xD9 {
+				return out, nil
+			}
+			continue
+		}
+
+		if i+2 > len(data) {
+			return nil, ErrMalformed
+		}
+		end := i + int(binary.BigEndian.Uint16(data[i:]))
+		if end > len(data) || end < i+2 {
+			return nil, ErrMalformed
+		}
+		if marker != 0xE1 && marker != 0xED && marker != 0xFE {
+			out = append(out, data[start:end]...)
+		}
+		i = end
+	}
+	return out, nil
+}
+
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// pngMetadataChunks are the ancillary chunks stripPNG drops.
+var pngMetadataChunks = map[string]bool{"eXIf": true, "tEXt": true, "iTXt": true, "zTXt": true, "tIME": true}
+
+func stripPNG(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, pngSignature) {
+		return nil, ErrMalformed
+	}
+
+	out := append(make([]byte, 0, len(data)), pngSignature...)
+	i := len(pngSignature)
+	for i < len(data) {
+		if i+8 > len(data) {
+			return nil, ErrMalformed
+		}
+		length := int(binary.BigEndian.Uint32(data[i:]))
+		chunkType := string(data[i+4 : i+8])
+		end := i + 12 + length
+		if length < 0 || end > len(data) {
+			return nil, ErrMalformed
+		}
+		if !pngMetadataChunks[chunkType] {
+			out = append(out, data[i:end]...)
+		}
+		i = end
+		if chunkType == "IEND" {
+			break
+		}
+	}
+	return out, nil
+}
+
+// CanThumbnail reports whether Thumbnail supports the content type.
+func CanThumbnail(contentType string) bool {
+	return contentType == TypeJPEG || contentType == TypePNG
+}
+
+// Thumbnail scales a JPEG or PNG image down so that neither side exceeds
+// maxSize and encodes it in the same format.
+func Thumbnail(contentType string, data []byte, maxSize int) ([]byte, error) {
+	if !CanThumbnail(contentType) {
+		return nil, fmt.Errorf("%w: no thumbnails for %s", ErrUnsupportedType, contentType)
+	}
+	if _, _, err := Dimensions(data); err != nil {
+		return nil, err
+	}
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+
+	width, height := fit(src.Bounds().Dx(), src.Bounds().Dy(), maxSize)
+	thumb := resize(src, width, height)
+
+	var buf bytes.Buffer
+	if contentType == TypeJPEG {
+		err = jpeg.Encode(&buf, thumb, &jpeg.Options{Quality: 80})
+	} else {
+		err = png.Encode(&buf, thumb)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// fit returns the size of a width×height image scaled down, keeping its
+// aspect ratio, so that neither side exceeds maxSize.
+func fit(width, height, maxSize int) (int, int) {
+	if width <= maxSize && height <= maxSize {
+		return width, height
+	}
+	if width >= height {
+		return maxSize, max(1, height*maxSize/width)
+	}
+	return max(1, width*maxSize/height), maxSize
+}
+
+// resize scales src to width×height by averaging the source pixels that
+// fall into each destination pixel. Only the band of source rows behind one
+// destination row is converted to RGBA at a time, so memory stays small
+// next to the decoded image however large it is.
+func resize(src image.Image, width, height int) *image.RGBA {
+	bounds := src.Bounds()
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+	band := image.NewRGBA(image.Rect(0, 0, srcW, (srcH+height-1)/height+1))
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0, y1 := y*srcH/height, max((y+1)*srcH/height, y*srcH/height+1)
+		draw.Draw(band, image.Rect(0, 0, srcW, y1-y0), src, image.Pt(bounds.Min.X, bounds.Min.Y+y0), draw.Src)
+		for x := 0; x < width; x++ {
+			x0, x1 := x*srcW/width, max((x+1)*srcW/width, x*srcW/width+1)
+
+			var sum [4]int
+			for sy := y0; sy < y1; sy++ {
+				row := band.Pix[(sy-y0)*band.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					for c := 0; c < 4; c++ {
+						sum[c] += int(row[sx*4+c])
+					}
+				}
+			}
+			n := (y1 - y0) * (x1 - x0)
+			offset := y*dst.Stride + x*4
+			for c := 0; c < 4; c++ {
+				dst.Pix[offset+c] = uint8(sum[c] / n)
+			}
+		}
+	}
+	return dst
+}
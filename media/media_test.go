@@ -0,0 +1,181 @@
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+func testImage(width, height int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	return img
+}
+
+func encodeJPEG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, testImage(width, height), nil); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func encodePNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, testImage(width, height)); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// withEXIF inserts an APP1 EXIF segment right after the SOI marker.
+func withEXIF(data []byte) []byte {
+	payload := []byte("Exif\x00\x00GPS 41.2995N 69.2401E")
+	segment := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+	segment = append(segment, payload...)
+
+	out := append([]byte{}, data[:2]...)
+	out = append(out, segment...)
+	return append(out, data[2:]...)
+}
+
+// withTextChunk inserts a tEXt chunk right after the IHDR chunk.
+func withTextChunk(data []byte) []byte {
+	payload := []byte("Comment\x00taken at 41.2995N 69.2401E")
+	chunk := make([]byte, 8, 12+len(payload))
+	binary.BigEndian.PutUint32(chunk, uint32(len(payload)))
+	copy(chunk[4:], "tEXt")
+	chunk = append(chunk, payload...)
+	chunk = binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE(chunk[4:]))
+
+	ihdrEnd := 8 + 12 + int(binary.BigEndian.Uint32(data[8:]))
+	out := append([]byte{}, data[:ihdrEnd]...)
+	out = append(out, chunk...)
+	return append(out, data[ihdrEnd:]...)
+}
+
+func TestSniff(t *testing.T) {
+	if got, err := Sniff(encodeJPEG(t, 4, 4)); err != nil || got != TypeJPEG {
+		t.Errorf("Sniff(jpeg) = %q, %v", got, err)
+	}
+	if got, err := Sniff(encodePNG(t, 4, 4)); err != nil || got != TypePNG {
+		t.Errorf("Sniff(png) = %q, %v", got, err)
+	}
+	if _, err := Sniff([]byte("<html><script>alert(1)</script>")); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("Sniff(html) error = %v, want ErrUnsupportedType", err)
+	}
+}
+
+func TestStripMetadataJPEG(t *testing.T) {
+	data := withEXIF(encodeJPEG(t, 16, 8))
+
+	stripped, err := StripMetadata(TypeJPEG, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(stripped, []byte("Exif")) || bytes.Contains(stripped, []byte("GPS")) {
+		t.Error("EXIF data survived stripping")
+	}
+	if len(stripped) >= len(data) {
+		t.Errorf("stripped size %d, want less than %d", len(stripped), len(data))
+	}
+	if _, err := jpeg.Decode(bytes.NewReader(stripped)); err != nil {
+		t.Errorf("stripped JPEG does not decode: %v", err)
+	}
+}
+
+func TestStripMetadataPNG(t *testing.T) {
+	data := withTextChunk(encodePNG(t, 16, 8))
+
+	stripped, err := StripMetadata(TypePNG, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(stripped, []byte("tEXt")) {
+		t.Error("tEXt chunk survived stripping")
+	}
+	if _, err := png.Decode(bytes.NewReader(stripped)); err != nil {
+		t.Errorf("stripped PNG does not decode: %v", err)
+	}
+}
+
+func TestStripMetadataMalformed(t *testing.T) {
+	if _, err := StripMetadata(TypeJPEG, []byte{0xFF, 0xD8, 0xFF, 0xE1, 0xFF}); !errors.Is(err, ErrMalformed) {
+		t.Errorf("truncated JPEG error = %v, want ErrMalformed", err)
+	}
+	if _, err := StripMetadata(TypePNG, []byte("\x89PNG\r\n\x1a\n\x00\x00\xff\xffIDAT")); !errors.Is(err, ErrMalformed) {
+		t.Errorf("truncated PNG error = %v, want ErrMalformed", err)
+	}
+}
+
+func TestThumbnail(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		data        []byte
+		wantW       int
+		wantH       int
+	}{
+		{"wide jpeg", TypeJPEG, encodeJPEG(t, 400, 200), 320, 160},
+		{"tall png", TypePNG, encodePNG(t, 100, 640), 50, 320},
+		{"small png", TypePNG, encodePNG(t, 40, 30), 40, 30},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			thumb, err := Thumbnail(tt.contentType, tt.data, 320)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, _ := Sniff(thumb); got != tt.contentType {
+				t.Errorf("thumbnail type = %q, want %q", got, tt.contentType)
+			}
+			w, h, err := Dimensions(thumb)
+			if err != nil || w != tt.wantW || h != tt.wantH {
+				t.Errorf("thumbnail is %dx%d (%v), want %dx%d", w, h, err, tt.wantW, tt.wantH)
+			}
+		})
+	}
+}
+
+func TestResizeAveragesBands(t *testing.T) {
+	// An offset bounds rectangle checks that bands are read from the right
+	// source rows.
+	src := image.NewGray(image.Rect(10, 20, 17, 25))
+	for y := 20; y < 25; y++ {
+		for x := 10; x < 17; x++ {
+			src.SetGray(x, y, color.Gray{Y: uint8(x*10 + y)})
+		}
+	}
+
+	dst := resize(src, 3, 2)
+	for y := 0; y < 2; y++ {
+		y0, y1 := y*5/2, max((y+1)*5/2, y*5/2+1)
+		for x := 0; x < 3; x++ {
+			x0, x1 := x*7/3, max((x+1)*7/3, x*7/3+1)
+			sum := 0
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					sum += int(src.GrayAt(10+sx, 20+sy).Y)
+				}
+			}
+			want := uint8(sum / ((y1 - y0) * (x1 - x0)))
+			if got := dst.RGBAAt(x, y); got.R != want || got.A != 255 {
+				t.Errorf("pixel %d,%d = %v, want gray %d", x, y, got, want)
+			}
+		}
+	}
+}
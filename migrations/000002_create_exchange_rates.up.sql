@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS exchange_rates (
+    currency VARCHAR(3) PRIMARY KEY,
+    units_per_usd DECIMAL(18, 6) NOT NULL CHECK (units_per_usd > 0),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO exchange_rates (currency, units_per_usd) VALUES
+    ('USD', 1),
+    ('EUR', 0.92),
+    ('GBP', 0.79),
+    ('JPY', 150),
+    ('RUB', 90),
+    ('TRY', 32),
+    ('UZS', 12600)
+ON CONFLICT (currency) DO NOTHING;
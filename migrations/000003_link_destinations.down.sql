@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS destinations_name_trgm_idx;
+DROP INDEX IF EXISTS itinerary_destinations_destination_id_idx;
+DROP INDEX IF EXISTS stories_destination_id_idx;
+
+ALTER TABLE itinerary_destinations DROP COLUMN IF EXISTS destination_id;
+ALTER TABLE stories DROP COLUMN IF EXISTS destination_id;
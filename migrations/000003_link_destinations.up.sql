@@ -0,0 +1,8 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE stories ADD COLUMN IF NOT EXISTS destination_id UUID REFERENCES destinations(id);
+ALTER TABLE itinerary_destinations ADD COLUMN IF NOT EXISTS destination_id UUID REFERENCES destinations(id);
+
+CREATE INDEX IF NOT EXISTS stories_destination_id_idx ON stories (destination_id);
+CREATE INDEX IF NOT EXISTS itinerary_destinations_destination_id_idx ON itinerary_destinations (destination_id);
+CREATE INDEX IF NOT EXISTS destinations_name_trgm_idx ON destinations USING GIN (lower(name) gin_trgm_ops);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS stories_coordinates_idx;
+DROP INDEX IF EXISTS destinations_coordinates_idx;
+
+ALTER TABLE itinerary_destinations DROP COLUMN IF EXISTS latitude, DROP COLUMN IF EXISTS longitude;
+ALTER TABLE stories DROP COLUMN IF EXISTS latitude, DROP COLUMN IF EXISTS longitude;
+ALTER TABLE destinations DROP COLUMN IF EXISTS latitude, DROP COLUMN IF EXISTS longitude;
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS itinerary_activities_destination_id_idx;
+
+ALTER TABLE itinerary_activities
+    DROP COLUMN IF EXISTS day_index,
+    DROP COLUMN IF EXISTS start_time,
+    DROP COLUMN IF EXISTS duration_minutes,
+    DROP COLUMN IF EXISTS position,
+    DROP COLUMN IF EXISTS cost,
+    DROP COLUMN IF EXISTS category;
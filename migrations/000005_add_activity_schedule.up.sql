@@ -0,0 +1,9 @@
+ALTER TABLE itinerary_activities
+    ADD COLUMN IF NOT EXISTS day_index INTEGER CHECK (day_index > 0),
+    ADD COLUMN IF NOT EXISTS start_time TIME,
+    ADD COLUMN IF NOT EXISTS duration_minutes INTEGER CHECK (duration_minutes >= 0),
+    ADD COLUMN IF NOT EXISTS position INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS cost DECIMAL(10, 2) CHECK (cost >= 0),
+    ADD COLUMN IF NOT EXISTS category VARCHAR(50);
+
+CREATE INDEX IF NOT EXISTS itinerary_activities_destination_id_idx ON itinerary_activities (destination_id, day_index, position);
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS share_links;
+
+DROP INDEX IF EXISTS followers_following_id_idx;
+
+ALTER TABLE itineraries DROP COLUMN IF EXISTS visibility;
+ALTER TABLE stories DROP COLUMN IF EXISTS visibility;
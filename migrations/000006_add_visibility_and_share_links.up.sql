@@ -0,0 +1,21 @@
+ALTER TABLE stories
+    ADD COLUMN IF NOT EXISTS visibility VARCHAR(20) NOT NULL DEFAULT 'public'
+        CHECK (visibility IN ('private', 'followers', 'unlisted', 'public'));
+
+ALTER TABLE itineraries
+    ADD COLUMN IF NOT EXISTS visibility VARCHAR(20) NOT NULL DEFAULT 'public'
+        CHECK (visibility IN ('private', 'followers', 'unlisted', 'public'));
+
+CREATE INDEX IF NOT EXISTS followers_following_id_idx ON followers (following_id);
+
+CREATE TABLE IF NOT EXISTS share_links (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    item_type VARCHAR(20) NOT NULL CHECK (item_type IN ('story', 'itinerary')),
+    item_id UUID NOT NULL,
+    created_by UUID REFERENCES users(id),
+    expires_at TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS share_links_item_idx ON share_links (item_type, item_id);
@@ -0,0 +1,4 @@
+ALTER TABLE itinerary_activities DROP COLUMN IF EXISTS last_modified_by, DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE itinerary_destinations DROP COLUMN IF EXISTS last_modified_by, DROP COLUMN IF EXISTS updated_at;
+
+DROP TABLE IF EXISTS itinerary_collaborators;
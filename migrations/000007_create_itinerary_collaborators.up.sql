@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS itinerary_collaborators (
+    itinerary_id UUID REFERENCES itineraries(id),
+    user_id UUID REFERENCES users(id),
+    role VARCHAR(20) NOT NULL CHECK (role IN ('editor', 'viewer')),
+    invited_by UUID REFERENCES users(id),
+    invited_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    accepted_at TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (itinerary_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS itinerary_collaborators_user_id_idx ON itinerary_collaborators (user_id);
+
+ALTER TABLE itinerary_destinations
+    ADD COLUMN IF NOT EXISTS last_modified_by UUID REFERENCES users(id),
+    ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP;
+
+ALTER TABLE itinerary_activities
+    ADD COLUMN IF NOT EXISTS last_modified_by UUID REFERENCES users(id),
+    ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP;
@@ -0,0 +1,2 @@
+ALTER TABLE itineraries DROP COLUMN IF EXISTS version;
+ALTER TABLE stories DROP COLUMN IF EXISTS version;
@@ -0,0 +1,3 @@
+ALTER TABLE stories ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+
+ALTER TABLE itineraries ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
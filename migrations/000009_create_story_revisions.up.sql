@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS story_revisions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    story_id UUID NOT NULL REFERENCES stories(id),
+    version BIGINT NOT NULL,
+    title VARCHAR(200) NOT NULL,
+    content TEXT NOT NULL,
+    location VARCHAR(100),
+    tags TEXT[] NOT NULL DEFAULT '{}',
+    edited_by UUID REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS story_revisions_story_id_idx ON story_revisions (story_id, version DESC);
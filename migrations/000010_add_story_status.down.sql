@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS stories_scheduled_publish_at_idx;
+
+ALTER TABLE stories DROP COLUMN IF EXISTS published_at, DROP COLUMN IF EXISTS publish_at, DROP COLUMN IF EXISTS status;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS itineraries_trash_idx;
+DROP INDEX IF EXISTS stories_trash_idx;
@@ -0,0 +1,3 @@
+CREATE INDEX IF NOT EXISTS stories_trash_idx ON stories (author_id, deleted_at) WHERE deleted_at > 0;
+
+CREATE INDEX IF NOT EXISTS itineraries_trash_idx ON itineraries (author_id, deleted_at) WHERE deleted_at > 0;
@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS media (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id UUID NOT NULL REFERENCES users(id),
+    content_type VARCHAR(50) NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    width INTEGER NOT NULL,
+    height INTEGER NOT NULL,
+    storage_key TEXT NOT NULL,
+    thumbnail_key TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS media_owner_id_idx ON media (owner_id);
+
+CREATE TABLE IF NOT EXISTS story_media (
+    story_id UUID REFERENCES stories(id),
+    media_id UUID REFERENCES media(id),
+    position INTEGER NOT NULL,
+    PRIMARY KEY (story_id, media_id)
+);
+
+CREATE INDEX IF NOT EXISTS story_media_media_id_idx ON story_media (media_id);
+
+CREATE TABLE IF NOT EXISTS itinerary_media (
+    itinerary_id UUID REFERENCES itineraries(id),
+    media_id UUID REFERENCES media(id),
+    position INTEGER NOT NULL,
+    PRIMARY KEY (itinerary_id, media_id)
+);
+
+CREATE INDEX IF NOT EXISTS itinerary_media_media_id_idx ON itinerary_media (media_id);
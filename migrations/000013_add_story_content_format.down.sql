@@ -0,0 +1,6 @@
+ALTER TABLE story_revisions
+    DROP COLUMN IF EXISTS content_format;
+
+ALTER TABLE stories
+    DROP COLUMN IF EXISTS content_html,
+    DROP COLUMN IF EXISTS content_format;
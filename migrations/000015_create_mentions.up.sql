@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS mentions (
+    item_type VARCHAR(20) NOT NULL CHECK (item_type IN ('story', 'comment')),
+    item_id UUID NOT NULL,
+    story_id UUID NOT NULL REFERENCES stories(id),
+    mentioned_user_id UUID NOT NULL REFERENCES users(id),
+    author_id UUID REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (item_type, item_id, mentioned_user_id)
+);
+
+CREATE INDEX IF NOT EXISTS mentions_mentioned_user_id_idx ON mentions (mentioned_user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS mentions_story_id_idx ON mentions (story_id);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS story_tags_tag_pattern_idx;
+DROP TABLE IF EXISTS tag_aliases;
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS tag_aliases (
+    alias VARCHAR(50) PRIMARY KEY,
+    tag VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (alias <> tag)
+);
+
+CREATE INDEX IF NOT EXISTS tag_aliases_alias_pattern_idx ON tag_aliases (alias text_pattern_ops);
+
+CREATE INDEX IF NOT EXISTS story_tags_tag_pattern_idx ON story_tags (tag text_pattern_ops);
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS collections (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id UUID NOT NULL REFERENCES users(id),
+    name VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    is_public BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (owner_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS collection_items (
+    collection_id UUID REFERENCES collections(id),
+    item_type VARCHAR(20) NOT NULL CHECK (item_type IN ('story', 'itinerary', 'tip')),
+    item_id UUID NOT NULL,
+    added_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (collection_id, item_type, item_id)
+);
+
+CREATE INDEX IF NOT EXISTS collection_items_item_idx ON collection_items (item_type, item_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS series_stories;
+DROP TABLE IF EXISTS series;
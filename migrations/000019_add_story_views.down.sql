@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS story_daily_views;
+
+ALTER TABLE stories DROP COLUMN IF EXISTS views_count;
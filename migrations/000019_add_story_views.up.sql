@@ -0,0 +1,8 @@
+ALTER TABLE stories ADD COLUMN IF NOT EXISTS views_count BIGINT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS story_daily_views (
+    story_id UUID REFERENCES stories(id),
+    day DATE NOT NULL,
+    views BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (story_id, day)
+);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS stories_published_at_idx;
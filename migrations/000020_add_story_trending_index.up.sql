@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS stories_published_at_idx ON stories (published_at) WHERE status = 'published' AND deleted_at = 0;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS followers_following_id_idx;
+CREATE INDEX IF NOT EXISTS followers_following_follower_idx ON followers (following_id, follower_id);
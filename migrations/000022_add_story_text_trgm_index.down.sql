@@ -0,0 +1 @@
+DROP INDEX IF EXISTS stories_text_trgm_idx;
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS stories_text_trgm_idx ON stories USING GIN ((title || ' ' || excerpt) gin_trgm_ops);
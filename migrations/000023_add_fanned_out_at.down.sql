@@ -0,0 +1,2 @@
+ALTER TABLE itineraries DROP COLUMN IF EXISTS fanned_out_at;
+ALTER TABLE stories DROP COLUMN IF EXISTS fanned_out_at;
@@ -0,0 +1,2 @@
+ALTER TABLE stories ADD COLUMN IF NOT EXISTS fanned_out_at TIMESTAMPTZ;
+ALTER TABLE itineraries ADD COLUMN IF NOT EXISTS fanned_out_at TIMESTAMPTZ;
@@ -0,0 +1,71 @@
+package scheduler
+
+import (
+	"Content-Service/config"
+	"Content-Service/storage/postgres"
+	"Content-Service/storage/redis"
+	"context"
+	"log/slog"
+	"time"
+)
+
+// FanOutWorker periodically takes new feed items off the fan-out queue and
+// writes them to followers' timelines. Replicas pop disjoint batches, so
+// every replica may run one.
+type FanOutWorker struct {
+	queue     *redis.FanOutQueue
+	log       *slog.Logger
+	interval  time.Duration
+	batchSize int
+	fanOut    func(context.Context, string, []string)
+}
+
+// NewFanOutWorker returns a FanOutWorker that calls fanOut with the type
+// and ids of the items it takes off the queue.
+func NewFanOutWorker(queue *redis.FanOutQueue, log *slog.Logger, cfg config.FeedConfig, fanOut func(context.Context, string, []string)) *FanOutWorker {
+	return &FanOutWorker{
+		queue:     queue,
+		log:       log,
+		interval:  time.Duration(cfg.FEED_FANOUT_INTERVAL_SECONDS) * time.Second,
+		batchSize: batchSize(cfg.FEED_FANOUT_BATCH_SIZE),
+		fanOut:    fanOut,
+	}
+}
+
+// Run fans out queued items every interval until ctx is cancelled. It
+// returns immediately when the interval is not positive.
+func (w *FanOutWorker) Run(ctx context.Context) {
+	if w.interval <= 0 {
+		w.log.Info("fan-out worker is disabled")
+		return
+	}
+	every(ctx, w.interval, w.drain)
+}
+
+// drain fans out batches until the queue is empty.
+func (w *FanOutWorker) drain(ctx context.Context) {
+	for {
+		refs, err := w.queue.Pop(ctx, w.batchSize)
+		if err != nil {
+			w.log.Error(err.Error())
+			return
+		}
+		for itemType, ids := range groupByType(refs) {
+			w.fanOut(ctx, itemType, ids)
+		}
+		if len(refs) > 0 {
+			w.log.Info("fanned out feed items", "count", len(refs))
+		}
+		if len(refs) < w.batchSize {
+			return
+		}
+	}
+}
+
+func groupByType(refs []postgres.FeedRef) map[string][]string {
+	ids := make(map[string][]string)
+	for _, ref := range refs {
+		ids[ref.ItemType] = append(ids[ref.ItemType], ref.ItemID)
+	}
+	return ids
+}
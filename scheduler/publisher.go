@@ -0,0 +1,62 @@
+package scheduler
+
+import (
+	"Content-Service/config"
+	"Content-Service/storage/postgres"
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Publisher periodically publishes scheduled stories whose publish time has
+// passed. Every replica may run one; PublishDueStories skips rows another
+// replica is already publishing.
+type Publisher struct {
+	repo      *postgres.ContentRepo
+	log       *slog.Logger
+	interval  time.Duration
+	batchSize int
+	onPublish func(context.Context, []string)
+}
+
+// NewPublisher returns a Publisher that calls onPublish, when not nil, with
+// the ids of every batch it publishes.
+func NewPublisher(repo *postgres.ContentRepo, log *slog.Logger, cfg config.SchedulerConfig, onPublish func(context.Context, []string)) *Publisher {
+	return &Publisher{
+		repo:      repo,
+		log:       log,
+		interval:  time.Duration(cfg.PUBLISH_INTERVAL_SECONDS) * time.Second,
+		batchSize: batchSize(cfg.PUBLISH_BATCH_SIZE),
+		onPublish: onPublish,
+	}
+}
+
+// Run publishes due stories every interval until ctx is cancelled. It
+// returns immediately when the interval is not positive.
+func (p *Publisher) Run(ctx context.Context) {
+	if p.interval <= 0 {
+		p.log.Info("story publisher is disabled")
+		return
+	}
+	every(ctx, p.interval, p.publishDue)
+}
+
+// publishDue publishes batches until no due stories are left.
+func (p *Publisher) publishDue(ctx context.Context) {
+	for {
+		ids, err := p.repo.PublishDueStories(ctx, p.batchSize)
+		if err != nil {
+			p.log.Error(err.Error())
+			return
+		}
+		if len(ids) > 0 {
+			p.log.Info("published scheduled stories", "count", len(ids))
+			if p.onPublish != nil {
+				p.onPublish(ctx, ids)
+			}
+		}
+		if len(ids) < p.batchSize {
+			return
+		}
+	}
+}
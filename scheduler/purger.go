@@ -0,0 +1,87 @@
+package scheduler
+
+import (
+	"Content-Service/config"
+	"Content-Service/storage/blob"
+	"Content-Service/storage/postgres"
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Purger periodically removes stories and itineraries that have stayed in
+// the trash longer than the retention window, and then the uploads nothing
+// refers to any more together with their blobs. Like the Publisher it is
+// safe to run on every replica.
+type Purger struct {
+	repo              *postgres.ContentRepo
+	blob              blob.Store
+	log               *slog.Logger
+	interval          time.Duration
+	retentionSeconds  int64
+	mediaGraceSeconds int64
+	batchSize         int
+}
+
+func NewPurger(repo *postgres.ContentRepo, store blob.Store, log *slog.Logger, cfg config.TrashConfig) *Purger {
+	return &Purger{
+		repo:              repo,
+		blob:              store,
+		log:               log,
+		interval:          time.Duration(cfg.PURGE_INTERVAL_SECONDS) * time.Second,
+		retentionSeconds:  int64(cfg.TRASH_RETENTION_DAYS) * 24 * 60 * 60,
+		mediaGraceSeconds: int64(cfg.MEDIA_ORPHAN_GRACE_HOURS) * 60 * 60,
+		batchSize:         batchSize(cfg.PURGE_BATCH_SIZE),
+	}
+}
+
+// Run purges expired items every interval until ctx is cancelled. It
+// returns immediately when the interval is not positive.
+func (p *Purger) Run(ctx context.Context) {
+	if p.interval <= 0 {
+		p.log.Info("trash purger is disabled")
+		return
+	}
+	every(ctx, p.interval, p.purgeExpired)
+}
+
+func (p *Purger) purgeExpired(ctx context.Context) {
+	p.purgeAll(ctx, "stories", p.repo.PurgeDeletedStories)
+	p.purgeAll(ctx, "itineraries", p.repo.PurgeDeletedItineraries)
+	p.purgeAll(ctx, "media", p.purgeOrphanedMedia)
+}
+
+// purgeAll purges batches until no expired items of one kind are left.
+func (p *Purger) purgeAll(ctx context.Context, kind string, purge func(context.Context, int64, int) (int, error)) {
+	for {
+		n, err := purge(ctx, p.retentionSeconds, p.batchSize)
+		if err != nil {
+			p.log.Error(err.Error())
+			return
+		}
+		if n > 0 {
+			p.log.Info("purged deleted "+kind, "count", n)
+		}
+		if n < p.batchSize {
+			return
+		}
+	}
+}
+
+// purgeOrphanedMedia deletes a batch of unreferenced uploads and then their
+// blobs. Uploads use their own grace period instead of the trash retention
+// passed by purgeAll. The rows go first so that a failed blob deletion only
+// leaves an unreachable file behind, never a record pointing at a missing
+// one.
+func (p *Purger) purgeOrphanedMedia(ctx context.Context, _ int64, limit int) (int, error) {
+	keys, n, err := p.repo.PurgeOrphanedMedia(ctx, p.mediaGraceSeconds, limit)
+	if err != nil {
+		return 0, err
+	}
+	for _, key := range keys {
+		if err := p.blob.Delete(ctx, key); err != nil {
+			p.log.Error(err.Error())
+		}
+	}
+	return n, nil
+}
@@ -0,0 +1,30 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+)
+
+const defaultBatchSize = 100
+
+// every calls fn immediately and then every interval until ctx is
+// cancelled.
+func every(ctx context.Context, interval time.Duration, fn func(context.Context)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		fn(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func batchSize(n int) int {
+	if n <= 0 {
+		return defaultBatchSize
+	}
+	return n
+}
@@ -0,0 +1,52 @@
+package scheduler
+
+import (
+	"Content-Service/storage/postgres"
+	"context"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestEveryRunsUntilCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	done := make(chan struct{})
+	go func() {
+		every(ctx, time.Millisecond, func(context.Context) {
+			calls++
+			if calls == 3 {
+				cancel()
+			}
+		})
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("every did not return after the context was cancelled")
+	}
+	if calls != 3 {
+		t.Errorf("fn was called %d times, want 3", calls)
+	}
+}
+
+func TestBatchSize(t *testing.T) {
+	for n, want := range map[int]int{-1: defaultBatchSize, 0: defaultBatchSize, 25: 25} {
+		if got := batchSize(n); got != want {
+			t.Errorf("batchSize(%d) = %d, want %d", n, got, want)
+		}
+	}
+}
+
+func TestGroupByType(t *testing.T) {
+	groups := groupByType([]postgres.FeedRef{
+		{ItemType: "story", ItemID: "a"},
+		{ItemType: "itinerary", ItemID: "b"},
+		{ItemType: "story", ItemID: "c"},
+	})
+	if len(groups) != 2 || !slices.Equal(groups["story"], []string{"a", "c"}) || !slices.Equal(groups["itinerary"], []string{"b"}) {
+		t.Errorf("groupByType = %v", groups)
+	}
+}
@@ -0,0 +1,66 @@
+package scheduler
+
+import (
+	"Content-Service/config"
+	"Content-Service/storage/postgres"
+	"Content-Service/storage/redis"
+	"context"
+	"log/slog"
+	"time"
+)
+
+// ViewFlusher periodically copies the unique viewer estimates kept in Redis
+// into Postgres. Replicas pop disjoint batches from Redis, so every replica
+// may run one.
+type ViewFlusher struct {
+	repo      *postgres.ContentRepo
+	views     *redis.ViewCounter
+	log       *slog.Logger
+	interval  time.Duration
+	batchSize int
+}
+
+func NewViewFlusher(repo *postgres.ContentRepo, views *redis.ViewCounter, log *slog.Logger, cfg config.ViewsConfig) *ViewFlusher {
+	return &ViewFlusher{
+		repo:      repo,
+		views:     views,
+		log:       log,
+		interval:  time.Duration(cfg.VIEWS_FLUSH_INTERVAL_SECONDS) * time.Second,
+		batchSize: batchSize(cfg.VIEWS_FLUSH_BATCH_SIZE),
+	}
+}
+
+// Run flushes view counts every interval until ctx is cancelled. It returns
+// immediately when the interval is not positive.
+func (f *ViewFlusher) Run(ctx context.Context) {
+	if f.interval <= 0 {
+		f.log.Info("view flusher is disabled")
+		return
+	}
+	every(ctx, f.interval, f.flush)
+}
+
+// flush stores batches until no stories with new views are left. A batch
+// that cannot be stored goes back to Redis for the next run.
+func (f *ViewFlusher) flush(ctx context.Context) {
+	for {
+		views, err := f.views.Pop(ctx, f.batchSize)
+		if err != nil {
+			f.log.Error(err.Error())
+			return
+		}
+		if err := f.repo.FlushStoryViews(ctx, views); err != nil {
+			f.log.Error(err.Error())
+			if err := f.views.Requeue(ctx, views); err != nil {
+				f.log.Error(err.Error())
+			}
+			return
+		}
+		if len(views) > 0 {
+			f.log.Info("flushed story views", "count", len(views))
+		}
+		if len(views) < f.batchSize {
+			return
+		}
+	}
+}
@@ -1,10 +1,14 @@
 package main
 
 import (
+	"Content-Service/config"
 	"Content-Service/genproto/content"
+	"Content-Service/scheduler"
 
 	"Content-Service/service"
+	"Content-Service/storage/blob"
 	"Content-Service/storage/postgres"
+	"context"
 	"fmt"
 	"google.golang.org/grpc"
 	"log"
@@ -23,7 +27,20 @@ func main() {
 		log.Fatalf("error while listening: %v", err)
 	}
 	defer lis.Close()
-	Service := service.NewContentService(db)
+	cfg := config.Load()
+	store, err := blob.NewStore(cfg.Media)
+	if err != nil {
+		log.Fatalf("error while opening media storage: %v", err)
+	}
+	Service := service.NewContentService(db, cfg, store)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go scheduler.NewPublisher(Service.Repo, Service.Log, cfg.Scheduler, Service.FanOutStories).Run(ctx)
+	go scheduler.NewPurger(Service.Repo, Service.Blob, Service.Log, cfg.Trash).Run(ctx)
+	go scheduler.NewViewFlusher(Service.Repo, Service.Views, Service.Log, cfg.Views).Run(ctx)
+	go scheduler.NewFanOutWorker(Service.FanOutQueue, Service.Log, cfg.Feed, Service.FanOut).Run(ctx)
+
 	server := grpc.NewServer()
 	content.RegisterContentServer(server, Service)
 	log.Printf("server listening at %v", lis.Addr())
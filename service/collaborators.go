@@ -0,0 +1,330 @@
+package service
+
+import (
+	pb "Content-Service/genproto/content"
+	"Content-Service/help"
+	"Content-Service/storage/postgres"
+	"context"
+	"database/sql"
+	"slices"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func (u *ContentService) InviteCollaborator(ctx context.Context, req *pb.InviteCollaboratorReq) (*pb.Collaborator, error) {
+	u.Log.Info("InviteCollaborator rpc method started")
+	if req.Role != postgres.RoleEditor && req.Role != postgres.RoleViewer {
+		u.Log.Error("invalid collaborator role")
+		return nil, status.Errorf(codes.InvalidArgument, "role must be %s or %s", postgres.RoleEditor, postgres.RoleViewer)
+	}
+	if req.InviteeId == "" || req.InviteeId == req.UserId {
+		u.Log.Error("invalid invitee")
+		return nil, status.Error(codes.InvalidArgument, "invitee_id must name another user")
+	}
+	if err := u.requireItineraryRole(ctx, req.ItineraryId, req.UserId, postgres.RoleOwner); err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+
+	res, err := u.Repo.InviteCollaborator(ctx, req)
+	if err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	u.Log.Info("InviteCollaborator rpc method finished")
+	return res, nil
+}
+
+func (u *ContentService) AcceptInvite(ctx context.Context, req *pb.AcceptInviteReq) (*pb.Collaborator, error) {
+	u.Log.Info("AcceptInvite rpc method started")
+	res, err := u.Repo.AcceptInvite(ctx, req)
+	if err == sql.ErrNoRows {
+		u.Log.Error("no pending invite")
+		return nil, status.Error(codes.NotFound, "no pending invite for this itinerary")
+	}
+	if err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	u.Log.Info("AcceptInvite rpc method finished")
+	return res, nil
+}
+
+// RemoveCollaborator lets the owner remove any collaborator or pending
+// invitation and lets a collaborator leave on their own.
+func (u *ContentService) RemoveCollaborator(ctx context.Context, req *pb.RemoveCollaboratorReq) (*pb.Void, error) {
+	u.Log.Info("RemoveCollaborator rpc method started")
+	if req.CollaboratorId != req.UserId {
+		if err := u.requireItineraryRole(ctx, req.ItineraryId, req.UserId, postgres.RoleOwner); err != nil {
+			u.Log.Error(err.Error())
+			return nil, err
+		}
+	}
+
+	removed, err := u.Repo.RemoveCollaborator(ctx, req.ItineraryId, req.CollaboratorId)
+	if err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	if !removed {
+		u.Log.Error("collaborator not found")
+		return nil, status.Error(codes.NotFound, "collaborator not found")
+	}
+	u.Log.Info("RemoveCollaborator rpc method finished")
+	return &pb.Void{}, nil
+}
+
+func (u *ContentService) AddItineraryDestination(ctx context.Context, req *pb.AddItineraryDestinationReq) (*pb.Destination, error) {
+	u.Log.Info("AddItineraryDestination rpc method started")
+	if req.Destination == nil {
+		u.Log.Error("destination is missing")
+		return nil, status.Error(codes.InvalidArgument, "destination is required")
+	}
+	if err := validateDestination(req.Destination); err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	if err := u.requireItineraryRole(ctx, req.ItineraryId, req.UserId, postgres.RoleOwner, postgres.RoleEditor); err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	err := u.checkItineraryEdit(ctx, req.ItineraryId, func(dests []*pb.Destination) []*pb.Destination {
+		return append(dests, req.Destination)
+	})
+	if err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+
+	res, err := u.Repo.AddItineraryDestination(ctx, req)
+	if err != nil {
+		u.Log.Error(err.Error())
+		return nil, destinationError(err)
+	}
+	u.Log.Info("AddItineraryDestination rpc method finished")
+	return res, nil
+}
+
+func (u *ContentService) UpdateItineraryDestination(ctx context.Context, req *pb.UpdateItineraryDestinationReq) (*pb.Destination, error) {
+	u.Log.Info("UpdateItineraryDestination rpc method started")
+	if req.Destination == nil {
+		u.Log.Error("destination is missing")
+		return nil, status.Error(codes.InvalidArgument, "destination is required")
+	}
+	if err := validateDestination(req.Destination); err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	itineraryID, err := u.Repo.ItineraryOfDestination(ctx, req.Id)
+	if err != nil {
+		err = childLookupError(err, "destination")
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	if err := u.requireItineraryRole(ctx, itineraryID, req.UserId, postgres.RoleOwner, postgres.RoleEditor); err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	err = u.checkItineraryEdit(ctx, itineraryID, func(dests []*pb.Destination) []*pb.Destination {
+		for i, dest := range dests {
+			if dest.Id == req.Id {
+				updated := proto.Clone(req.Destination).(*pb.Destination)
+				updated.Activities = dest.Activities
+				dests[i] = updated
+			}
+		}
+		return dests
+	})
+	if err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+
+	res, err := u.Repo.UpdateItineraryDestination(ctx, req)
+	if err != nil {
+		u.Log.Error(err.Error())
+		return nil, destinationError(err)
+	}
+	u.Log.Info("UpdateItineraryDestination rpc method finished")
+	return res, nil
+}
+
+func (u *ContentService) RemoveItineraryDestination(ctx context.Context, req *pb.RemoveItineraryChildReq) (*pb.Void, error) {
+	u.Log.Info("RemoveItineraryDestination rpc method started")
+	itineraryID, err := u.Repo.ItineraryOfDestination(ctx, req.Id)
+	if err != nil {
+		err = childLookupError(err, "destination")
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	if err := u.requireItineraryRole(ctx, itineraryID, req.UserId, postgres.RoleOwner, postgres.RoleEditor); err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+
+	if err := u.Repo.DeleteItineraryDestination(ctx, req); err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	u.Log.Info("RemoveItineraryDestination rpc method finished")
+	return &pb.Void{}, nil
+}
+
+func (u *ContentService) AddItineraryActivity(ctx context.Context, req *pb.AddItineraryActivityReq) (*pb.Activities, error) {
+	u.Log.Info("AddItineraryActivity rpc method started")
+	if req.Activity == nil {
+		u.Log.Error("activity is missing")
+		return nil, status.Error(codes.InvalidArgument, "activity is required")
+	}
+	if err := validateActivity(req.Activity); err != nil {
+		u.Log.Error(err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	itineraryID, err := u.Repo.ItineraryOfDestination(ctx, req.DestinationId)
+	if err != nil {
+		err = childLookupError(err, "destination")
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	if err := u.requireItineraryRole(ctx, itineraryID, req.UserId, postgres.RoleOwner, postgres.RoleEditor); err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	err = u.checkItineraryEdit(ctx, itineraryID, func(dests []*pb.Destination) []*pb.Destination {
+		for _, dest := range dests {
+			if dest.Id == req.DestinationId {
+				dest.Activities = append(dest.Activities, req.Activity)
+			}
+		}
+		return dests
+	})
+	if err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+
+	res, err := u.Repo.AddItineraryActivity(ctx, req)
+	if err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	u.Log.Info("AddItineraryActivity rpc method finished")
+	return res, nil
+}
+
+func (u *ContentService) UpdateItineraryActivity(ctx context.Context, req *pb.UpdateItineraryActivityReq) (*pb.Activities, error) {
+	u.Log.Info("UpdateItineraryActivity rpc method started")
+	if req.Activity == nil {
+		u.Log.Error("activity is missing")
+		return nil, status.Error(codes.InvalidArgument, "activity is required")
+	}
+	if err := validateActivity(req.Activity); err != nil {
+		u.Log.Error(err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	itineraryID, _, err := u.Repo.ItineraryOfActivity(ctx, req.Id)
+	if err != nil {
+		err = childLookupError(err, "activity")
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	if err := u.requireItineraryRole(ctx, itineraryID, req.UserId, postgres.RoleOwner, postgres.RoleEditor); err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	err = u.checkItineraryEdit(ctx, itineraryID, func(dests []*pb.Destination) []*pb.Destination {
+		for _, dest := range dests {
+			for i, activity := range dest.Activities {
+				if activity.Id == req.Id {
+					dest.Activities[i] = req.Activity
+				}
+			}
+		}
+		return dests
+	})
+	if err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+
+	res, err := u.Repo.UpdateItineraryActivity(ctx, req)
+	if err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	u.Log.Info("UpdateItineraryActivity rpc method finished")
+	return res, nil
+}
+
+func (u *ContentService) RemoveItineraryActivity(ctx context.Context, req *pb.RemoveItineraryChildReq) (*pb.Void, error) {
+	u.Log.Info("RemoveItineraryActivity rpc method started")
+	itineraryID, _, err := u.Repo.ItineraryOfActivity(ctx, req.Id)
+	if err != nil {
+		err = childLookupError(err, "activity")
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	if err := u.requireItineraryRole(ctx, itineraryID, req.UserId, postgres.RoleOwner, postgres.RoleEditor); err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+
+	if err := u.Repo.DeleteItineraryActivity(ctx, req); err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	u.Log.Info("RemoveItineraryActivity rpc method finished")
+	return &pb.Void{}, nil
+}
+
+// requireItineraryRole returns NotFound when the itinerary does not exist and
+// PermissionDenied unless userID holds one of the allowed roles on it.
+func (u *ContentService) requireItineraryRole(ctx context.Context, itineraryID, userID string, allowed ...string) error {
+	role, err := u.Repo.ItineraryRole(ctx, itineraryID, userID)
+	if err == sql.ErrNoRows {
+		return status.Error(codes.NotFound, "itinerary not found")
+	}
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(allowed, role) {
+		return status.Error(codes.PermissionDenied, "you are not allowed to change this itinerary")
+	}
+	return nil
+}
+
+// checkItineraryEdit loads an itinerary, applies edit to its destinations
+// and rejects the change if the result has hard date errors.
+func (u *ContentService) checkItineraryEdit(ctx context.Context, itineraryID string, edit func([]*pb.Destination) []*pb.Destination) error {
+	itinerary, err := u.Repo.GetItinerariesById(ctx, &pb.GetItinerariesByIdReq{Id: itineraryID})
+	if err != nil {
+		return err
+	}
+
+	errs, _ := checkItinerary(itinerary.StartDate, itinerary.EndDate, edit(itinerary.Destination))
+	if len(errs) > 0 {
+		return itineraryIssuesError(errs)
+	}
+	return nil
+}
+
+func validateDestination(dest *pb.Destination) error {
+	if err := help.ValidateCoordinates(dest.Latitude, dest.Longitude); err != nil {
+		return status.Errorf(codes.InvalidArgument, "destination %q: %v", dest.Name, err)
+	}
+	for _, activity := range dest.Activities {
+		if err := validateActivity(activity); err != nil {
+			return status.Errorf(codes.InvalidArgument, "destination %q activity %q: %v", dest.Name, activity.Text, err)
+		}
+	}
+	return nil
+}
+
+func childLookupError(err error, kind string) error {
+	if err == sql.ErrNoRows {
+		return status.Errorf(codes.NotFound, "%s not found", kind)
+	}
+	return err
+}
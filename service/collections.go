@@ -0,0 +1,192 @@
+package service
+
+import (
+	pb "Content-Service/genproto/content"
+	"Content-Service/storage/postgres"
+	"context"
+	"database/sql"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultCollectionsLimit = 20
+	maxCollectionsLimit     = 100
+)
+
+func (u *ContentService) CreateCollection(ctx context.Context, req *pb.CreateCollectionReq) (*pb.Collection, error) {
+	u.Log.Info("CreateCollection rpc method started")
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		err := status.Error(codes.InvalidArgument, "name is required")
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+
+	res, err := u.Repo.CreateCollection(ctx, req)
+	if err == sql.ErrNoRows {
+		u.Log.Error("duplicate collection name")
+		return nil, status.Errorf(codes.AlreadyExists, "a collection named %q already exists", req.Name)
+	}
+	if err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	u.Log.Info("CreateCollection rpc method finished")
+	return res, nil
+}
+
+// AddToCollection saves a story, itinerary or tip the user can see to one
+// of their collections.
+func (u *ContentService) AddToCollection(ctx context.Context, req *pb.CollectionItemReq) (*pb.CollectionItem, error) {
+	u.Log.Info("AddToCollection rpc method started")
+	if err := validateCollectionItemType(req.ItemType); err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	if err := u.requireCollectionOwner(ctx, req.CollectionId, req.UserId); err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+
+	if req.ItemType == postgres.ItemTip {
+		exists, err := u.Repo.TipExists(ctx, req.ItemId)
+		if err != nil {
+			u.Log.Error(err.Error())
+			return nil, err
+		}
+		if !exists {
+			u.Log.Error("tip not found")
+			return nil, status.Error(codes.NotFound, "tip not found")
+		}
+	} else if err := u.checkAccess(ctx, req.ItemType, req.ItemId, req.UserId, req.ShareToken); err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+
+	res, err := u.Repo.AddToCollection(ctx, req)
+	if err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	u.Log.Info("AddToCollection rpc method finished")
+	return res, nil
+}
+
+func (u *ContentService) RemoveFromCollection(ctx context.Context, req *pb.CollectionItemReq) (*pb.Void, error) {
+	u.Log.Info("RemoveFromCollection rpc method started")
+	if err := validateCollectionItemType(req.ItemType); err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	if err := u.requireCollectionOwner(ctx, req.CollectionId, req.UserId); err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+
+	removed, err := u.Repo.RemoveFromCollection(ctx, req)
+	if err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	if !removed {
+		u.Log.Error("collection item not found")
+		return nil, status.Errorf(codes.NotFound, "%s is not in this collection", req.ItemType)
+	}
+	u.Log.Info("RemoveFromCollection rpc method finished")
+	return &pb.Void{}, nil
+}
+
+// ListCollections lists a user's collections; other viewers only see the
+// public ones.
+func (u *ContentService) ListCollections(ctx context.Context, req *pb.ListCollectionsReq) (*pb.ListCollectionsRes, error) {
+	u.Log.Info("ListCollections rpc method started")
+	if req.UserId == "" {
+		err := status.Error(codes.InvalidArgument, "user_id is required")
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	if req.Limit <= 0 {
+		req.Limit = defaultCollectionsLimit
+	}
+	if req.Limit > maxCollectionsLimit {
+		req.Limit = maxCollectionsLimit
+	}
+	if req.Offset < 0 {
+		req.Offset = 0
+	}
+
+	res, err := u.Repo.ListCollections(ctx, req)
+	if err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	u.Log.Info("ListCollections rpc method finished")
+	return res, nil
+}
+
+// GetCollection returns a public collection or one of the viewer's own with
+// the items the viewer can see. Private collections of other users are
+// reported as missing.
+func (u *ContentService) GetCollection(ctx context.Context, req *pb.GetCollectionReq) (*pb.GetCollectionRes, error) {
+	u.Log.Info("GetCollection rpc method started")
+	collection, err := u.Repo.GetCollection(ctx, req.CollectionId, req.ViewerId)
+	if err == sql.ErrNoRows || (err == nil && !collection.IsPublic && collection.OwnerId != req.ViewerId) {
+		u.Log.Error("collection not found")
+		return nil, status.Error(codes.NotFound, "collection not found")
+	}
+	if err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	if req.Limit <= 0 {
+		req.Limit = defaultCollectionsLimit
+	}
+	if req.Limit > maxCollectionsLimit {
+		req.Limit = maxCollectionsLimit
+	}
+	if req.Offset < 0 {
+		req.Offset = 0
+	}
+
+	items, total, err := u.Repo.ListCollectionItems(ctx, req)
+	if err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	u.Log.Info("GetCollection rpc method finished")
+	return &pb.GetCollectionRes{
+		Collection: collection,
+		Items:      items,
+		Total:      total,
+		Limit:      req.Limit,
+		Offset:     req.Offset,
+	}, nil
+}
+
+// requireCollectionOwner returns NotFound for an unknown collection and
+// PermissionDenied unless userID owns it.
+func (u *ContentService) requireCollectionOwner(ctx context.Context, collectionID, userID string) error {
+	collection, err := u.Repo.GetCollection(ctx, collectionID, userID)
+	if err == sql.ErrNoRows {
+		return status.Error(codes.NotFound, "collection not found")
+	}
+	if err != nil {
+		return err
+	}
+	if collection.OwnerId != userID {
+		return status.Error(codes.PermissionDenied, "only the owner can change a collection")
+	}
+	return nil
+}
+
+func validateCollectionItemType(itemType string) error {
+	switch itemType {
+	case postgres.ItemStory, postgres.ItemItinerary, postgres.ItemTip:
+		return nil
+	}
+	return status.Errorf(codes.InvalidArgument, "item_type must be %s, %s or %s",
+		postgres.ItemStory, postgres.ItemItinerary, postgres.ItemTip)
+}
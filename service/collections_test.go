@@ -0,0 +1,21 @@
+package service
+
+import (
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestValidateCollectionItemType(t *testing.T) {
+	for _, itemType := range []string{"story", "itinerary", "tip"} {
+		if err := validateCollectionItemType(itemType); err != nil {
+			t.Errorf("validateCollectionItemType(%q) = %v, want nil", itemType, err)
+		}
+	}
+	for _, itemType := range []string{"", "comment", "Story"} {
+		if err := validateCollectionItemType(itemType); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("validateCollectionItemType(%q) = %v, want InvalidArgument", itemType, err)
+		}
+	}
+}
@@ -1,44 +1,107 @@
 package service
 
 import (
+	"Content-Service/config"
 	pb "Content-Service/genproto/content"
+	"Content-Service/help"
 	"Content-Service/logger"
+	"Content-Service/storage/blob"
 	"Content-Service/storage/postgres"
 	"Content-Service/storage/redis"
 	"context"
 	"database/sql"
 	"log/slog"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultNearbyRadiusKm = 25
+	maxNearbyRadiusKm     = 500
+	defaultNearbyLimit    = 20
+	maxNearbyLimit        = 100
 )
 
 type ContentService struct {
 	pb.UnimplementedContentServer
-	Repo *postgres.ContentRepo
-	Log  *slog.Logger
+	Repo        *postgres.ContentRepo
+	Log         *slog.Logger
+	Cfg         *config.Config
+	Blob        blob.Store
+	Views       *redis.ViewCounter
+	Trending    *redis.Trending
+	Timelines   *redis.Timelines
+	Related     *redis.RelatedCache
+	FanOutQueue *redis.FanOutQueue
 }
 
-func NewContentService(db *sql.DB) *ContentService {
+func NewContentService(db *sql.DB, cfg *config.Config, store blob.Store) *ContentService {
+	rdb := redis.ConnectDB()
 	return &ContentService{
-		Repo: postgres.NewContentRepository(db),
-		Log:  logger.NewLogger(),
+		Repo:        postgres.NewContentRepository(db),
+		Log:         logger.NewLogger(),
+		Cfg:         cfg,
+		Blob:        store,
+		Views:       redis.NewViewCounter(rdb),
+		Trending:    redis.NewTrending(rdb),
+		Timelines:   redis.NewTimelines(rdb, cfg.Feed.FEED_TIMELINE_SIZE),
+		Related:     redis.NewRelatedCache(rdb, time.Duration(cfg.Related.RELATED_CACHE_TTL_SECONDS)*time.Second),
+		FanOutQueue: redis.NewFanOutQueue(rdb),
 	}
 }
 func (u *ContentService) CreateStories(ctx context.Context, req *pb.CreateStoriesRequest) (*pb.CreateStoriesResponse, error) {
 	u.Log.Info("CreateStories rpc method started")
+	if err := resolvePublishing(req, time.Now()); err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	if err := help.ValidateCoordinates(req.Latitude, req.Longitude); err != nil {
+		u.Log.Error(err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := validateVisibility(req.Visibility); err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	if err := validateContentFormat(req.ContentFormat); err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
 	res, err := u.Repo.CreateStory(ctx, req)
 	if err != nil {
 		u.Log.Error(err.Error())
-		return nil, err
+		return nil, destinationError(mediaError(err))
+	}
+	if res.Status == postgres.StatusPublished {
+		u.queueFanOut(ctx, postgres.ItemStory, []string{res.Id})
 	}
+	u.invalidateRelated(ctx, res.Id, nil, res.Tags)
 	u.Log.Info("CreateStories rpc method finished")
 	return res, nil
 }
 func (u *ContentService) UpdateStories(ctx context.Context, req *pb.UpdateStoriesReq) (*pb.UpdateStoriesRes, error) {
 	u.Log.Info("UpdateStories rpc method started")
-	res, err := u.Repo.UpdateStory(ctx, req)
+	if err := validateStoryUpdate(req); err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	if err := u.requireStoryAuthor(ctx, req.Id, req.UserId); err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	oldTags, err := u.Repo.StoryTags(ctx, req.Id)
 	if err != nil {
 		u.Log.Error(err.Error())
 		return nil, err
 	}
+	res, err := u.Repo.UpdateStory(ctx, req)
+	if err != nil {
+		u.Log.Error(err.Error())
+		return nil, destinationError(mediaError(versionError(err)))
+	}
+	u.invalidateRelated(ctx, req.Id, oldTags, res.Tags)
 	u.Log.Info("UpdateStories rpc method finished")
 	return res, nil
 }
@@ -65,13 +128,26 @@ func (u *ContentService) GetAllStories(ctx context.Context, req *pb.GetAllStorie
 	return res, nil
 }
 
-func (u *ContentService) GetStory(ctx context.Context, req *pb.StoryId) (*pb.GetStoryRes, error) {
+func (u *ContentService) GetStory(ctx context.Context, req *pb.GetStoryReq) (*pb.GetStoryRes, error) {
 	u.Log.Info("GetStory rpc method started")
-	res, err := u.Repo.GetStoryById(ctx, req)
+	if err := u.checkAccess(ctx, postgres.ItemStory, req.Id, req.ViewerId, req.ShareToken); err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	res, err := u.Repo.GetStoryById(ctx, &pb.StoryId{Id: req.Id})
+	if err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	res.Series, err = u.Repo.GetStorySeries(ctx, req.Id, req.ViewerId)
 	if err != nil {
 		u.Log.Error(err.Error())
 		return nil, err
 	}
+	if req.ViewerId != "" && req.ViewerId != res.Author.UserId {
+		// Counting is best effort; a Redis outage must not hide stories.
+		u.recordView(ctx, req.Id, req.ViewerId)
+	}
 	u.Log.Info("GetStory rpc method finished")
 	return res, nil
 }
@@ -83,12 +159,17 @@ func (u *ContentService) CommentStory(ctx context.Context, req *pb.CommentStoryR
 		u.Log.Error(err.Error())
 		return nil, err
 	}
+	u.addTrendingPoints(ctx, req.StoryId, u.Cfg.Trending.TRENDING_COMMENT_WEIGHT)
 	u.Log.Info("CommentStory rpc method finished")
 	return res, nil
 }
 
 func (u *ContentService) GetCommentsOfStory(ctx context.Context, req *pb.GetCommentsOfStoryReq) (*pb.GetCommentsOfStoryRes, error) {
 	u.Log.Info("GetCommentsOfStory rpc method started")
+	if err := u.checkAccess(ctx, postgres.ItemStory, req.StoryId, req.ViewerId, req.ShareToken); err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
 	res, err := u.Repo.GetCommentsOfStory(ctx, req)
 	if err != nil {
 		u.Log.Error(err.Error())
@@ -105,34 +186,93 @@ func (u *ContentService) Like(ctx context.Context, req *pb.LikeReq) (*pb.LikeRes
 		u.Log.Error(err.Error())
 		return nil, err
 	}
+	u.addTrendingPoints(ctx, req.StoryId, u.Cfg.Trending.TRENDING_LIKE_WEIGHT)
 	u.Log.Info("Like rpc method finished")
 	return res, nil
 }
 
 func (u *ContentService) Itineraries(ctx context.Context, req *pb.ItinerariesReq) (*pb.ItinerariesRes, error) {
 	u.Log.Info("Itineraries rpc method started")
+	if err := validateVisibility(req.Visibility); err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	for _, dest := range req.Destinations {
+		if err := validateDestination(dest); err != nil {
+			u.Log.Error(err.Error())
+			return nil, err
+		}
+	}
+	errs, warnings := checkItinerary(req.StartDate, req.EndDate, req.Destinations)
+	if len(errs) > 0 {
+		err := itineraryIssuesError(errs)
+		u.Log.Error(err.Error())
+		return nil, err
+	}
 	res, err := u.Repo.Itineraries(ctx, req)
 	if err != nil {
 		u.Log.Error(err.Error())
-		return nil, err
+		return nil, destinationError(mediaError(err))
 	}
+	u.queueFanOut(ctx, postgres.ItemItinerary, []string{res.Id})
+	res.Warnings = warnings
 	u.Log.Info("Itineraries rpc method finished")
 	return res, nil
 }
 
 func (u *ContentService) UpdateItineraries(ctx context.Context, req *pb.UpdateItinerariesReq) (*pb.ItinerariesRes, error) {
 	u.Log.Info("UpdateItineraries rpc method started")
+	if err := validateVisibility(req.Visibility); err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	allowed := []string{postgres.RoleOwner, postgres.RoleEditor}
+	if req.Visibility != "" {
+		allowed = []string{postgres.RoleOwner}
+	}
+	if err := u.requireItineraryRole(ctx, req.Id, req.UserId, allowed...); err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	var warnings []*pb.ItineraryIssue
+	if req.StartDate != "" || req.EndDate != "" {
+		current, err := u.Repo.GetItinerariesById(ctx, &pb.GetItinerariesByIdReq{Id: req.Id})
+		if err != nil {
+			u.Log.Error(err.Error())
+			return nil, err
+		}
+		startDate, endDate := current.StartDate, current.EndDate
+		if req.StartDate != "" {
+			startDate = req.StartDate
+		}
+		if req.EndDate != "" {
+			endDate = req.EndDate
+		}
+
+		var errs []*pb.ItineraryIssue
+		errs, warnings = checkItinerary(startDate, endDate, current.Destination)
+		if len(errs) > 0 {
+			err := itineraryIssuesError(errs)
+			u.Log.Error(err.Error())
+			return nil, err
+		}
+	}
 	res, err := u.Repo.UpdateItineraries(ctx, req)
 	if err != nil {
 		u.Log.Error(err.Error())
-		return nil, err
+		return nil, versionError(err)
 	}
+	res.Warnings = warnings
 	u.Log.Info("UpdateItineraries rpc method finished")
 	return res, nil
 }
 
-func (u *ContentService) DeleteItineraries(ctx context.Context, req *pb.StoryId) (*pb.Void, error) {
+func (u *ContentService) DeleteItineraries(ctx context.Context, req *pb.DeleteItinerariesReq) (*pb.Void, error) {
 	u.Log.Info("DeleteItineraries rpc method started")
+	if err := u.requireItineraryRole(ctx, req.Id, req.UserId, postgres.RoleOwner); err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
 	err := u.Repo.DeleteItineraries(ctx, req)
 	if err != nil {
 		u.Log.Error(err.Error())
@@ -151,13 +291,20 @@ func (u *ContentService) GetItineraries(ctx context.Context, req *pb.GetItinerar
 	u.Log.Info("GetItineraries rpc method finished")
 	return res, nil
 }
-func (u *ContentService) GetItinerariesById(ctx context.Context, req *pb.StoryId) (*pb.GetItinerariesByIdRes, error) {
+func (u *ContentService) GetItinerariesById(ctx context.Context, req *pb.GetItinerariesByIdReq) (*pb.GetItinerariesByIdRes, error) {
 	u.Log.Info("GetItinerariesById rpc method started")
+	if err := u.checkAccess(ctx, postgres.ItemItinerary, req.Id, req.ViewerId, req.ShareToken); err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
 	res, err := u.Repo.GetItinerariesById(ctx, req)
 	if err != nil {
 		u.Log.Error(err.Error())
 		return nil, err
 	}
+	if req.IncludeRoute {
+		res.Legs, res.TotalDistanceKm = buildRoute(res.Destination, travelModes(u.Cfg.Route))
+	}
 	u.Log.Info("GetItinerariesById rpc method finished")
 	return res, nil
 }
@@ -251,3 +398,49 @@ func (u *ContentService) TopDestinations(ctx context.Context, req *pb.Void) (*pb
 	u.Log.Info("TopDestinations rpc method finished")
 	return res, nil
 }
+
+func (u *ContentService) EstimateItineraryBudget(ctx context.Context, req *pb.EstimateItineraryBudgetReq) (*pb.EstimateItineraryBudgetRes, error) {
+	u.Log.Info("EstimateItineraryBudget rpc method started")
+	if err := u.checkAccess(ctx, postgres.ItemItinerary, req.ItineraryId, req.ViewerId, req.ShareToken); err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	res, err := u.Repo.EstimateItineraryBudget(ctx, req)
+	if err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	u.Log.Info("EstimateItineraryBudget rpc method finished")
+	return res, nil
+}
+
+func (u *ContentService) GetNearby(ctx context.Context, req *pb.GetNearbyReq) (*pb.GetNearbyRes, error) {
+	u.Log.Info("GetNearby rpc method started")
+	if err := help.ValidatePoint(req.Latitude, req.Longitude); err != nil {
+		u.Log.Error(err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if req.Kind != "" && req.Kind != postgres.NearbyDestinations && req.Kind != postgres.NearbyStories {
+		u.Log.Error("invalid nearby kind " + req.Kind)
+		return nil, status.Errorf(codes.InvalidArgument, "kind must be %q, %q or empty", postgres.NearbyDestinations, postgres.NearbyStories)
+	}
+	if req.RadiusKm <= 0 {
+		req.RadiusKm = defaultNearbyRadiusKm
+	}
+	if req.RadiusKm > maxNearbyRadiusKm {
+		req.RadiusKm = maxNearbyRadiusKm
+	}
+	if req.Limit <= 0 {
+		req.Limit = defaultNearbyLimit
+	}
+	if req.Limit > maxNearbyLimit {
+		req.Limit = maxNearbyLimit
+	}
+	res, err := u.Repo.GetNearby(ctx, req)
+	if err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	u.Log.Info("GetNearby rpc method finished")
+	return res, nil
+}
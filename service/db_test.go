@@ -0,0 +1,57 @@
+package service
+
+import (
+	"Content-Service/config"
+	"Content-Service/storage/postgres"
+	"Content-Service/storage/redis"
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+// newTestService returns a service backed by the configured database and a
+// local Redis, skipping the test when either is unavailable.
+func newTestService(t *testing.T) *ContentService {
+	t.Helper()
+	db, err := postgres.ConnectDB()
+	if err != nil {
+		t.Skipf("database unavailable: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	rdb := redis.ConnectDB()
+	if err := rdb.Ping(context.Background()).Err(); err != nil {
+		t.Skipf("redis unavailable: %v", err)
+	}
+	t.Cleanup(func() { rdb.Close() })
+
+	cfg := config.Load()
+	return &ContentService{
+		Repo:        postgres.NewContentRepository(db),
+		Log:         slog.New(slog.NewTextHandler(io.Discard, nil)),
+		Cfg:         cfg,
+		Views:       redis.NewViewCounter(rdb),
+		Trending:    redis.NewTrending(rdb),
+		Timelines:   redis.NewTimelines(rdb, cfg.Feed.FEED_TIMELINE_SIZE),
+		Related:     redis.NewRelatedCache(rdb, time.Minute),
+		FanOutQueue: redis.NewFanOutQueue(rdb),
+	}
+}
+
+// createTestUser inserts a user with a random username and returns its id.
+func createTestUser(t *testing.T, u *ContentService) string {
+	t.Helper()
+	query := `
+        INSERT INTO users (username, email, password, full_name)
+        SELECT name, name || '@example.com', 'secret', 'Test User'
+        FROM (SELECT 'test_' || substr(md5(random()::TEXT), 1, 16) AS name) n
+        RETURNING id
+    `
+	var id string
+	if err := u.Repo.DB.QueryRow(query).Scan(&id); err != nil {
+		t.Fatalf("failed to create test user: %v", err)
+	}
+	return id
+}
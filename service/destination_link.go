@@ -0,0 +1,18 @@
+package service
+
+import (
+	"Content-Service/storage/postgres"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// destinationError reports a destination_id missing from the catalogue as
+// InvalidArgument. Other errors are returned unchanged.
+func destinationError(err error) error {
+	if errors.Is(err, postgres.ErrDestinationNotFound) {
+		return status.Error(codes.InvalidArgument, "destination_id does not match a catalogue destination")
+	}
+	return err
+}
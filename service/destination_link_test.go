@@ -0,0 +1,20 @@
+package service
+
+import (
+	"Content-Service/storage/postgres"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDestinationError(t *testing.T) {
+	if code := status.Code(destinationError(postgres.ErrDestinationNotFound)); code != codes.InvalidArgument {
+		t.Errorf("destinationError(ErrDestinationNotFound) code = %v, want InvalidArgument", code)
+	}
+	other := errors.New("boom")
+	if destinationError(other) != other {
+		t.Error("destinationError changed an unrelated error")
+	}
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"Content-Service/export"
+	pb "Content-Service/genproto/content"
+	"Content-Service/storage/postgres"
+	"bufio"
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// exportChunkSize is the largest payload sent in a single ExportChunk.
+const exportChunkSize = 32 * 1024
+
+type exportStream interface {
+	Send(*pb.ExportChunk) error
+}
+
+// chunkWriter sends every write as one ExportChunk. The first chunk also
+// carries the content type and file name of the export.
+type chunkWriter struct {
+	stream      exportStream
+	contentType string
+	filename    string
+	sent        bool
+}
+
+func (w *chunkWriter) Write(p []byte) (int, error) {
+	chunk := &pb.ExportChunk{Data: append([]byte(nil), p...)}
+	if !w.sent {
+		chunk.ContentType = w.contentType
+		chunk.Filename = w.filename
+		w.sent = true
+	}
+	if err := w.stream.Send(chunk); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (u *ContentService) ExportItinerary(req *pb.ExportItineraryReq, stream pb.Content_ExportItineraryServer) error {
+	u.Log.Info("ExportItinerary rpc method started")
+	ctx := stream.Context()
+
+	if err := u.checkAccess(ctx, postgres.ItemItinerary, req.ItineraryId, req.ViewerId, req.ShareToken); err != nil {
+		u.Log.Error(err.Error())
+		return err
+	}
+	itinerary, err := u.Repo.GetItinerariesById(ctx, &pb.GetItinerariesByIdReq{Id: req.ItineraryId})
+	if err != nil {
+		u.Log.Error(err.Error())
+		return err
+	}
+
+	err = u.writeExport(stream, req.Format, "itinerary-"+itinerary.Id, itinerary.Title, func(enc export.Encoder) error {
+		var catalogueIDs []string
+		for _, dest := range itinerary.Destination {
+			if dest.DestinationId != "" {
+				catalogueIDs = append(catalogueIDs, dest.DestinationId)
+			}
+			if dest.Latitude == nil || dest.Longitude == nil {
+				continue
+			}
+
+			var activities []string
+			for _, activity := range dest.Activities {
+				activities = append(activities, activity.Text)
+			}
+			if err := enc.Point(export.Point{
+				Kind:       export.KindDestination,
+				Name:       dest.Name,
+				Latitude:   *dest.Latitude,
+				Longitude:  *dest.Longitude,
+				StartDate:  dest.StartDate,
+				EndDate:    dest.EndDate,
+				Activities: activities,
+			}); err != nil {
+				return err
+			}
+		}
+
+		if !req.IncludeStories || len(catalogueIDs) == 0 {
+			return nil
+		}
+		return u.exportStories(ctx, enc, itinerary.Author.UserId, req.ViewerId, catalogueIDs)
+	})
+	if err != nil {
+		u.Log.Error(err.Error())
+		return err
+	}
+	u.Log.Info("ExportItinerary rpc method finished")
+	return nil
+}
+
+func (u *ContentService) ExportStories(req *pb.ExportStoriesReq, stream pb.Content_ExportStoriesServer) error {
+	u.Log.Info("ExportStories rpc method started")
+	ctx := stream.Context()
+
+	err := u.writeExport(stream, req.Format, "stories-"+req.UserId, "Stories", func(enc export.Encoder) error {
+		return u.exportStories(ctx, enc, req.UserId, req.ViewerId, nil)
+	})
+	if err != nil {
+		u.Log.Error(err.Error())
+		return err
+	}
+	u.Log.Info("ExportStories rpc method finished")
+	return nil
+}
+
+// writeExport encodes a document with title in format to stream, calling
+// write to emit its points.
+func (u *ContentService) writeExport(stream exportStream, format, name, title string, write func(export.Encoder) error) error {
+	cw := &chunkWriter{stream: stream}
+	buf := bufio.NewWriterSize(cw, exportChunkSize)
+
+	enc, err := export.NewEncoder(format, buf)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	cw.contentType = enc.ContentType()
+	cw.filename = name + "." + enc.Extension()
+
+	if err := enc.Begin(title); err != nil {
+		return err
+	}
+	if err := write(enc); err != nil {
+		return err
+	}
+	if err := enc.End(); err != nil {
+		return err
+	}
+	return buf.Flush()
+}
+
+func (u *ContentService) exportStories(ctx context.Context, enc export.Encoder, authorID, viewerID string, destinationIDs []string) error {
+	return u.Repo.StreamGeotaggedStories(ctx, authorID, viewerID, destinationIDs, func(story *pb.GetStoryRes) error {
+		return enc.Point(export.Point{
+			ID:        story.Id,
+			Kind:      export.KindStory,
+			Name:      story.Title,
+			Latitude:  *story.Latitude,
+			Longitude: *story.Longitude,
+			CreatedAt: story.CreatedAt,
+		})
+	})
+}
@@ -0,0 +1,213 @@
+package service
+
+import (
+	pb "Content-Service/genproto/content"
+	"Content-Service/help"
+	"Content-Service/storage/postgres"
+	"context"
+	"sort"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultFeedLimit = 20
+	maxFeedLimit     = 100
+
+	defaultFanOutBatchSize = 1000
+)
+
+// GetFeed returns the stories and itineraries of the authors a user
+// follows, newest first, a page at a time. Pass next_cursor back as cursor
+// to read the next page; it is empty after the last one.
+//
+// When fan-out is enabled, items fanned out to the user come from their
+// Redis timeline and the rest from Postgres. A timeline is only trusted
+// while it returns a full page, so pages past its oldest item, or any page
+// while Redis is down, are read from Postgres alone.
+func (u *ContentService) GetFeed(ctx context.Context, req *pb.GetFeedReq) (*pb.GetFeedRes, error) {
+	u.Log.Info("GetFeed rpc method started")
+	if req.UserId == "" {
+		err := status.Error(codes.InvalidArgument, "user_id is required")
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	cursor, err := help.ParseFeedCursor(req.Cursor)
+	if err != nil {
+		u.Log.Error(err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if req.Limit <= 0 {
+		req.Limit = defaultFeedLimit
+	}
+	if req.Limit > maxFeedLimit {
+		req.Limit = maxFeedLimit
+	}
+
+	var entries []postgres.FeedEntry
+	var horizon *help.FeedCursor
+	all := true
+	if refs := u.timelineRefs(ctx, req.UserId, cursor, req.Limit); int64(len(refs)) == req.Limit {
+		entries, err = u.Repo.GetFeedEntries(ctx, req.UserId, cursor, refs)
+		if err != nil {
+			u.Log.Error(err.Error())
+			return nil, err
+		}
+		last := refs[len(refs)-1]
+		horizon = &help.FeedCursor{Time: last.At, ItemType: last.ItemType, ItemID: last.ItemID}
+		all = false
+	}
+
+	pulled, err := u.Repo.GetFeed(ctx, req.UserId, cursor, req.Limit, all)
+	if err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	entries = append(entries, pulled...)
+
+	items, next := pageFeed(entries, horizon, req.Limit)
+	u.Log.Info("GetFeed rpc method finished")
+	return &pb.GetFeedRes{Items: items, NextCursor: next}, nil
+}
+
+// timelineRefs reads the next items of a user's timeline after the cursor,
+// or nothing when fan-out is disabled or Redis fails.
+func (u *ContentService) timelineRefs(ctx context.Context, userID string, cursor help.FeedCursor, limit int64) []postgres.FeedRef {
+	if u.Cfg.Feed.FEED_FANOUT_MIN_FOLLOWERS <= 0 {
+		return nil
+	}
+	// The timeline is read up to and including the cursor's time, which
+	// returns at least the item at the cursor again; read one more so it
+	// does not cost a full page.
+	refs, err := u.Timelines.Before(ctx, userID, cursor.Time, limit+1)
+	if err != nil {
+		u.Log.Error(err.Error())
+		return nil
+	}
+	return refsAfter(refs, cursor, limit)
+}
+
+// refsAfter returns up to limit of the newest-first refs that come after
+// the cursor in the feed.
+func refsAfter(refs []postgres.FeedRef, cursor help.FeedCursor, limit int64) []postgres.FeedRef {
+	var after []postgres.FeedRef
+	for _, ref := range refs {
+		if int64(len(after)) == limit {
+			break
+		}
+		at := help.FeedCursor{Time: ref.At, ItemType: ref.ItemType, ItemID: ref.ItemID}
+		if cursor.IsZero() || at.Less(cursor) {
+			after = append(after, ref)
+		}
+	}
+	return after
+}
+
+// pageFeed orders entries newest first, drops duplicates and returns the
+// first limit as a page with the cursor of the next one. When horizon is
+// set, entries after it are not known to be complete and are left for
+// later pages.
+func pageFeed(entries []postgres.FeedEntry, horizon *help.FeedCursor, limit int64) ([]*pb.FeedItem, string) {
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[j].Cursor.Less(entries[i].Cursor)
+	})
+
+	var items []*pb.FeedItem
+	var last help.FeedCursor
+	for i, e := range entries {
+		if i > 0 && !entries[i-1].Cursor.Less(e.Cursor) && !e.Cursor.Less(entries[i-1].Cursor) {
+			continue
+		}
+		if horizon != nil && e.Cursor.Less(*horizon) {
+			break
+		}
+		if int64(len(items)) == limit {
+			return items, last.String()
+		}
+		e.Item.FeedAt = e.Cursor.Time.Format(time.RFC3339Nano)
+		items = append(items, e.Item)
+		last = e.Cursor
+	}
+
+	switch {
+	case horizon != nil:
+		return items, horizon.String()
+	case int64(len(items)) == limit:
+		return items, last.String()
+	}
+	return items, ""
+}
+
+// FanOutStories queues newly published stories for fan-out to their
+// authors' followers.
+func (u *ContentService) FanOutStories(ctx context.Context, ids []string) {
+	u.queueFanOut(ctx, postgres.ItemStory, ids)
+}
+
+// queueFanOut hands new feed items to the fan-out worker when fan-out is
+// enabled. Failures are only logged; items that are never fanned out stay
+// in the feed query.
+func (u *ContentService) queueFanOut(ctx context.Context, itemType string, ids []string) {
+	if u.Cfg.Feed.FEED_FANOUT_MIN_FOLLOWERS <= 0 {
+		return
+	}
+	if err := u.FanOutQueue.Push(ctx, itemType, ids); err != nil {
+		u.Log.Error(err.Error())
+	}
+}
+
+// FanOut writes feed items of authors with at least
+// FEED_FANOUT_MIN_FOLLOWERS followers to their followers' timelines and
+// marks them as fanned out. It runs in the fan-out worker. Failures are
+// only logged; items that were not marked stay in the feed query.
+func (u *ContentService) FanOut(ctx context.Context, itemType string, ids []string) {
+	minFollowers := u.Cfg.Feed.FEED_FANOUT_MIN_FOLLOWERS
+	if minFollowers <= 0 || len(ids) == 0 {
+		return
+	}
+
+	refs, err := u.Repo.FeedRefs(ctx, itemType, ids)
+	if err != nil {
+		u.Log.Error(err.Error())
+		return
+	}
+	for _, ref := range refs {
+		if err := u.fanOutRef(ctx, ref, minFollowers); err != nil {
+			u.Log.Error(err.Error())
+		}
+	}
+}
+
+// fanOutRef fans out one item, reading followers in batches. Followers are
+// counted before they are read, so anyone who follows the author later is
+// left to the feed query.
+func (u *ContentService) fanOutRef(ctx context.Context, ref postgres.FeedRef, minFollowers int64) error {
+	count, at, err := u.Repo.FanOutAudience(ctx, ref.AuthorID)
+	if err != nil || count < minFollowers {
+		return err
+	}
+
+	batch := u.Cfg.Feed.FEED_FANOUT_BATCH_SIZE
+	if batch <= 0 {
+		batch = defaultFanOutBatchSize
+	}
+	after := ""
+	for {
+		followers, err := u.Repo.FollowerIDs(ctx, ref.AuthorID, after, batch)
+		if err != nil {
+			return err
+		}
+		if len(followers) > 0 {
+			if err := u.Timelines.Add(ctx, followers, ref); err != nil {
+				return err
+			}
+			after = followers[len(followers)-1]
+		}
+		if len(followers) < batch {
+			break
+		}
+	}
+	return u.Repo.MarkFannedOut(ctx, ref, at)
+}
@@ -0,0 +1,169 @@
+package service
+
+import (
+	pb "Content-Service/genproto/content"
+	"Content-Service/help"
+	"Content-Service/storage/postgres"
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// feedID turns the one-letter name of a test item into a UUID that sorts
+// the same way, since feed cursors only carry UUIDs.
+func feedID(name string) string {
+	return fmt.Sprintf("00000000-0000-0000-0000-%012x", name[0])
+}
+
+func feedEntry(minutes int, id string) postgres.FeedEntry {
+	at := time.Date(2024, 5, 6, 12, 0, 0, 0, time.UTC).Add(time.Duration(minutes) * time.Minute)
+	return postgres.FeedEntry{
+		Cursor: help.FeedCursor{Time: at, ItemType: "story", ItemID: feedID(id)},
+		Item:   &pb.FeedItem{ItemType: "story", Story: &pb.Stories{StoryId: id}},
+	}
+}
+
+func itemIDs(items []*pb.FeedItem) []string {
+	var ids []string
+	for _, item := range items {
+		ids = append(ids, item.Story.StoryId)
+	}
+	return ids
+}
+
+func TestPageFeed(t *testing.T) {
+	entries := []postgres.FeedEntry{feedEntry(1, "a"), feedEntry(3, "c"), feedEntry(2, "b"), feedEntry(3, "c")}
+
+	items, next := pageFeed(entries, nil, 2)
+	if got := itemIDs(items); len(got) != 2 || got[0] != "c" || got[1] != "b" {
+		t.Fatalf("first page = %v, want [c b]", got)
+	}
+	cursor, err := help.ParseFeedCursor(next)
+	if err != nil || cursor.ItemID != feedID("b") {
+		t.Errorf("next cursor = %+v, %v, want the position of b", cursor, err)
+	}
+
+	if items, next := pageFeed(entries, nil, 5); len(items) != 3 || next != "" {
+		t.Errorf("last page = %v, %q, want 3 items and no cursor", itemIDs(items), next)
+	}
+}
+
+func TestPageFeedHorizon(t *testing.T) {
+	horizon := feedEntry(2, "h").Cursor
+	entries := []postgres.FeedEntry{feedEntry(3, "c"), feedEntry(1, "a")}
+
+	items, next := pageFeed(entries, &horizon, 5)
+	if got := itemIDs(items); len(got) != 1 || got[0] != "c" {
+		t.Errorf("page = %v, want only the item before the horizon", got)
+	}
+	if next != horizon.String() {
+		t.Errorf("next cursor = %q, want the horizon", next)
+	}
+}
+
+func TestRefsAfter(t *testing.T) {
+	ref := func(minutes int, id string) postgres.FeedRef {
+		c := feedEntry(minutes, id).Cursor
+		return postgres.FeedRef{ItemType: c.ItemType, ItemID: c.ItemID, At: c.Time}
+	}
+	refs := []postgres.FeedRef{ref(3, "c"), ref(2, "b"), ref(2, "a"), ref(1, "z")}
+
+	if got := refsAfter(refs, help.FeedCursor{}, 2); len(got) != 2 || got[0].ItemID != feedID("c") {
+		t.Errorf("refsAfter(start) = %+v, want the first 2 refs", got)
+	}
+	got := refsAfter(refs, feedEntry(2, "b").Cursor, 5)
+	if len(got) != 2 || got[0].ItemID != feedID("a") || got[1].ItemID != feedID("z") {
+		t.Errorf("refsAfter(b) = %+v, want [a z]", got)
+	}
+}
+
+func TestGetFeedPagesThroughTimeline(t *testing.T) {
+	u := newTestService(t)
+	u.Cfg.Feed.FEED_FANOUT_MIN_FOLLOWERS = 1
+	ctx := context.Background()
+	author, follower := createTestUser(t, u), createTestUser(t, u)
+	if _, err := u.Repo.DB.Exec(`INSERT INTO followers (follower_id, following_id) VALUES ($1, $2)`, follower, author); err != nil {
+		t.Fatal(err)
+	}
+
+	var want []string
+	for _, title := range []string{"first", "second", "third"} {
+		story, err := u.CreateStories(ctx, &pb.CreateStoriesRequest{Title: title, Content: title, UserId: author})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want = append([]string{story.Id}, want...)
+	}
+	drainFanOut(t, u)
+
+	var got []string
+	cursor := ""
+	for page := 0; page <= len(want); page++ {
+		res, err := u.GetFeed(ctx, &pb.GetFeedReq{UserId: follower, Limit: 1, Cursor: cursor})
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, itemIDs(res.Items)...)
+		if res.NextCursor == "" {
+			break
+		}
+		if res.NextCursor == cursor {
+			t.Fatalf("page %d returned its own cursor again", page)
+		}
+		cursor = res.NextCursor
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("feed = %v, want %v", got, want)
+	}
+}
+
+func TestGetFeedShowsItemsFannedOutBeforeFollowing(t *testing.T) {
+	u := newTestService(t)
+	u.Cfg.Feed.FEED_FANOUT_MIN_FOLLOWERS = 1
+	ctx := context.Background()
+	author, early, late := createTestUser(t, u), createTestUser(t, u), createTestUser(t, u)
+	follow := func(follower string) {
+		t.Helper()
+		if _, err := u.Repo.DB.Exec(`INSERT INTO followers (follower_id, following_id) VALUES ($1, $2)`, follower, author); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	follow(early)
+	story, err := u.CreateStories(ctx, &pb.CreateStoriesRequest{Title: "before", Content: "before", UserId: author})
+	if err != nil {
+		t.Fatal(err)
+	}
+	drainFanOut(t, u)
+	follow(late)
+
+	for _, follower := range []string{early, late} {
+		res, err := u.GetFeed(ctx, &pb.GetFeedReq{UserId: follower})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := itemIDs(res.Items); len(got) != 1 || got[0] != story.Id {
+			t.Errorf("feed of %s = %v, want [%s]", follower, got, story.Id)
+		}
+	}
+}
+
+// drainFanOut does the fan-out worker's job for everything queued so far.
+func drainFanOut(t *testing.T, u *ContentService) {
+	t.Helper()
+	ctx := context.Background()
+	for {
+		refs, err := u.FanOutQueue.Pop(ctx, 100)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(refs) == 0 {
+			return
+		}
+		for _, ref := range refs {
+			u.FanOut(ctx, ref.ItemType, []string{ref.ItemID})
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	pb "Content-Service/genproto/content"
+	"Content-Service/help"
+	"Content-Service/storage/postgres"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (u *ContentService) CheckItinerary(ctx context.Context, req *pb.CheckItineraryReq) (*pb.CheckItineraryRes, error) {
+	u.Log.Info("CheckItinerary rpc method started")
+	var startDate, endDate string
+	var destinations []*pb.Destination
+
+	switch {
+	case req.ItineraryId != "":
+		if err := u.checkAccess(ctx, postgres.ItemItinerary, req.ItineraryId, req.ViewerId, ""); err != nil {
+			u.Log.Error(err.Error())
+			return nil, err
+		}
+		itinerary, err := u.Repo.GetItinerariesById(ctx, &pb.GetItinerariesByIdReq{Id: req.ItineraryId})
+		if err != nil {
+			u.Log.Error(err.Error())
+			return nil, err
+		}
+		startDate, endDate, destinations = itinerary.StartDate, itinerary.EndDate, itinerary.Destination
+	case req.Itinerary != nil:
+		startDate, endDate, destinations = req.Itinerary.StartDate, req.Itinerary.EndDate, req.Itinerary.Destinations
+	default:
+		u.Log.Error("CheckItinerary called without an itinerary")
+		return nil, status.Error(codes.InvalidArgument, "either itinerary_id or itinerary is required")
+	}
+
+	errs, warnings := checkItinerary(startDate, endDate, destinations)
+	u.Log.Info("CheckItinerary rpc method finished")
+	return &pb.CheckItineraryRes{
+		Valid:    len(errs) == 0,
+		Errors:   errs,
+		Warnings: warnings,
+	}, nil
+}
+
+// checkItinerary validates the dates of an itinerary and its destinations,
+// returning the hard errors and soft warnings as proto issues.
+func checkItinerary(startDate, endDate string, destinations []*pb.Destination) (errs, warnings []*pb.ItineraryIssue) {
+	stays := make([]help.Stay, 0, len(destinations))
+	for _, dest := range destinations {
+		stay := help.Stay{
+			Name:      dest.Name,
+			StartDate: dest.StartDate,
+			EndDate:   dest.EndDate,
+		}
+		for _, activity := range dest.Activities {
+			if activity.DayIndex > 0 {
+				stay.ActivityDays = append(stay.ActivityDays, int(activity.DayIndex))
+			} else {
+				stay.Activities++
+			}
+		}
+		stays = append(stays, stay)
+	}
+
+	hard, soft := help.CheckItineraryDates(startDate, endDate, stays)
+	return toItineraryIssues(hard), toItineraryIssues(soft)
+}
+
+func toItineraryIssues(issues []help.Issue) []*pb.ItineraryIssue {
+	var out []*pb.ItineraryIssue
+	for _, issue := range issues {
+		out = append(out, &pb.ItineraryIssue{
+			Code:        issue.Code,
+			Message:     issue.Message,
+			Destination: issue.Destination,
+			StartDate:   issue.StartDate,
+			EndDate:     issue.EndDate,
+		})
+	}
+	return out
+}
+
+// itineraryIssuesError turns hard itinerary errors into an InvalidArgument
+// status listing every problem.
+func itineraryIssuesError(errs []*pb.ItineraryIssue) error {
+	messages := make([]string, 0, len(errs))
+	for _, issue := range errs {
+		message := issue.Message
+		if issue.Destination != "" {
+			message = issue.Destination + ": " + message
+		}
+		messages = append(messages, message)
+	}
+	return status.Error(codes.InvalidArgument, "invalid itinerary: "+strings.Join(messages, "; "))
+}
+
+// validateActivity checks the scheduling fields of a single activity.
+func validateActivity(activity *pb.Activities) error {
+	if activity.DayIndex < 0 {
+		return fmt.Errorf("day_index must not be negative")
+	}
+	if activity.DurationMinutes < 0 {
+		return fmt.Errorf("duration_minutes must not be negative")
+	}
+	if activity.StartTime != "" {
+		if _, err := help.ParseClock(activity.StartTime); err != nil {
+			return err
+		}
+	}
+	if activity.Cost != "" {
+		cost, err := strconv.ParseFloat(activity.Cost, 64)
+		if err != nil || cost < 0 {
+			return fmt.Errorf("cost %q must be a non-negative number", activity.Cost)
+		}
+	}
+	return nil
+}
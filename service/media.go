@@ -0,0 +1,241 @@
+package service
+
+import (
+	pb "Content-Service/genproto/content"
+	"Content-Service/media"
+	"Content-Service/storage/blob"
+	"Content-Service/storage/postgres"
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UploadMedia receives an image as a stream of chunks. The first message
+// carries the uploader; every message carries the next part of the file.
+// The image is checked, stripped of its metadata and stored together with
+// a thumbnail before it is recorded.
+func (u *ContentService) UploadMedia(stream pb.Content_UploadMediaServer) error {
+	u.Log.Info("UploadMedia rpc method started")
+	ctx := stream.Context()
+
+	userID, data, err := receiveUpload(stream, u.Cfg.Media.MEDIA_MAX_BYTES)
+	if err != nil {
+		u.Log.Error(err.Error())
+		return err
+	}
+	if userID == "" {
+		err := status.Error(codes.InvalidArgument, "user_id is required")
+		u.Log.Error(err.Error())
+		return err
+	}
+
+	contentType, err := media.Sniff(data)
+	if err != nil {
+		u.Log.Error(err.Error())
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	data, err = media.StripMetadata(contentType, data)
+	if err != nil {
+		u.Log.Error(err.Error())
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	width, height, err := media.Dimensions(data)
+	if err != nil {
+		u.Log.Error(err.Error())
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	prefix, err := mediaKeyPrefix()
+	if err != nil {
+		u.Log.Error(err.Error())
+		return err
+	}
+	storageKey := prefix + "/original" + media.Extension(contentType)
+	if err := u.Blob.Put(ctx, storageKey, bytes.NewReader(data), contentType); err != nil {
+		u.Log.Error(err.Error())
+		return err
+	}
+
+	var thumbnailKey string
+	if media.CanThumbnail(contentType) {
+		thumbnail, err := media.Thumbnail(contentType, data, u.Cfg.Media.MEDIA_THUMBNAIL_SIZE)
+		if err != nil {
+			u.Log.Error(err.Error())
+			u.deleteBlobs(ctx, storageKey)
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		thumbnailKey = prefix + "/thumbnail" + media.Extension(contentType)
+		if err := u.Blob.Put(ctx, thumbnailKey, bytes.NewReader(thumbnail), contentType); err != nil {
+			u.Log.Error(err.Error())
+			u.deleteBlobs(ctx, storageKey)
+			return err
+		}
+	}
+
+	res, err := u.Repo.CreateMedia(ctx, &pb.Media{
+		OwnerId:     userID,
+		ContentType: contentType,
+		SizeBytes:   int64(len(data)),
+		Width:       int32(width),
+		Height:      int32(height),
+	}, storageKey, thumbnailKey)
+	if err != nil {
+		u.Log.Error(err.Error())
+		u.deleteBlobs(ctx, storageKey, thumbnailKey)
+		return err
+	}
+
+	u.Log.Info("UploadMedia rpc method finished")
+	return stream.SendAndClose(res)
+}
+
+// DownloadMedia streams an upload, or its thumbnail, to its owner or to
+// anyone who can read a story or itinerary it is attached to.
+func (u *ContentService) DownloadMedia(req *pb.DownloadMediaReq, stream pb.Content_DownloadMediaServer) error {
+	u.Log.Info("DownloadMedia rpc method started")
+	ctx := stream.Context()
+
+	m, storageKey, thumbnailKey, err := u.Repo.GetMedia(ctx, req.MediaId)
+	if err == sql.ErrNoRows {
+		return status.Error(codes.NotFound, "media not found")
+	}
+	if err != nil {
+		u.Log.Error(err.Error())
+		return err
+	}
+	if err := u.checkMediaAccess(ctx, m, req.UserId); err != nil {
+		u.Log.Error(err.Error())
+		return err
+	}
+
+	key := storageKey
+	if req.Thumbnail {
+		if thumbnailKey == "" {
+			return status.Error(codes.NotFound, "media has no thumbnail")
+		}
+		key = thumbnailKey
+	}
+
+	r, err := u.Blob.Get(ctx, key)
+	if errors.Is(err, blob.ErrNotFound) {
+		err = status.Error(codes.NotFound, "media not found")
+	}
+	if err != nil {
+		u.Log.Error(err.Error())
+		return err
+	}
+	defer r.Close()
+
+	w := bufio.NewWriterSize(&chunkWriter{
+		stream:      stream,
+		contentType: m.ContentType,
+		filename:    m.Id + media.Extension(m.ContentType),
+	}, exportChunkSize)
+	if _, err := io.Copy(w, r); err != nil {
+		u.Log.Error(err.Error())
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		u.Log.Error(err.Error())
+		return err
+	}
+
+	u.Log.Info("DownloadMedia rpc method finished")
+	return nil
+}
+
+// checkMediaAccess returns NotFound unless viewerID owns the upload or can
+// read something it is attached to.
+func (u *ContentService) checkMediaAccess(ctx context.Context, m *pb.Media, viewerID string) error {
+	if viewerID != "" && viewerID == m.OwnerId {
+		return nil
+	}
+
+	refs, err := u.Repo.MediaRefs(ctx, m.Id)
+	if err != nil {
+		return err
+	}
+	for _, ref := range refs {
+		visible, err := u.Repo.CanView(ctx, ref.ItemType, ref.ItemID, viewerID, false)
+		if err != nil {
+			return err
+		}
+		if visible {
+			return nil
+		}
+	}
+
+	return status.Error(codes.NotFound, "media not found")
+}
+
+type uploadStream interface {
+	Recv() (*pb.UploadMediaReq, error)
+}
+
+// receiveUpload reads an upload stream to the end and returns the uploader
+// and the file. Uploads larger than maxBytes are rejected as soon as the
+// limit is crossed.
+func receiveUpload(stream uploadStream, maxBytes int64) (string, []byte, error) {
+	var userID string
+	var data []byte
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", nil, err
+		}
+		if userID == "" {
+			userID = req.UserId
+		}
+		if int64(len(data)+len(req.Data)) > maxBytes {
+			return "", nil, status.Errorf(codes.InvalidArgument, "media must not exceed %d bytes", maxBytes)
+		}
+		data = append(data, req.Data...)
+	}
+
+	if len(data) == 0 {
+		return "", nil, status.Error(codes.InvalidArgument, "media is empty")
+	}
+	return userID, data, nil
+}
+
+// mediaKeyPrefix returns a new random blob key prefix for an upload.
+func mediaKeyPrefix() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate media key: %v", err)
+	}
+	return "media/" + hex.EncodeToString(b), nil
+}
+
+// deleteBlobs removes the blobs of an upload that could not be recorded.
+func (u *ContentService) deleteBlobs(ctx context.Context, keys ...string) {
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+		if err := u.Blob.Delete(ctx, key); err != nil {
+			u.Log.Error(err.Error())
+		}
+	}
+}
+
+// mediaError reports attachments of unknown or foreign media as
+// InvalidArgument. Other errors are returned unchanged.
+func mediaError(err error) error {
+	if errors.Is(err, postgres.ErrMediaNotFound) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return err
+}
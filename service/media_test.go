@@ -0,0 +1,62 @@
+package service
+
+import (
+	pb "Content-Service/genproto/content"
+	"Content-Service/storage/postgres"
+	"fmt"
+	"io"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeUploadStream struct {
+	reqs []*pb.UploadMediaReq
+}
+
+func (s *fakeUploadStream) Recv() (*pb.UploadMediaReq, error) {
+	if len(s.reqs) == 0 {
+		return nil, io.EOF
+	}
+	req := s.reqs[0]
+	s.reqs = s.reqs[1:]
+	return req, nil
+}
+
+func TestReceiveUpload(t *testing.T) {
+	stream := &fakeUploadStream{reqs: []*pb.UploadMediaReq{
+		{UserId: "u1", Data: []byte("abc")},
+		{Data: []byte("def")},
+	}}
+	userID, data, err := receiveUpload(stream, 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if userID != "u1" || string(data) != "abcdef" {
+		t.Errorf("receiveUpload = %q, %q, want %q, %q", userID, data, "u1", "abcdef")
+	}
+}
+
+func TestReceiveUploadRejects(t *testing.T) {
+	tests := map[string][]*pb.UploadMediaReq{
+		"too large": {{UserId: "u1", Data: []byte("abcd")}, {Data: []byte("efg")}},
+		"empty":     {{UserId: "u1"}},
+	}
+	for name, reqs := range tests {
+		_, _, err := receiveUpload(&fakeUploadStream{reqs: reqs}, 6)
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%s: error = %v, want InvalidArgument", name, err)
+		}
+	}
+}
+
+func TestMediaError(t *testing.T) {
+	if code := status.Code(mediaError(postgres.ErrMediaNotFound)); code != codes.InvalidArgument {
+		t.Errorf("code = %v, want InvalidArgument", code)
+	}
+	other := fmt.Errorf("boom")
+	if mediaError(other) != other {
+		t.Error("other errors should be returned unchanged")
+	}
+}
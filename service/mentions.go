@@ -0,0 +1,42 @@
+package service
+
+import (
+	pb "Content-Service/genproto/content"
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultMentionsLimit = 20
+	maxMentionsLimit     = 100
+)
+
+// ListMentions lists the stories and story comments that mention a user,
+// limited to those the viewer can see.
+func (u *ContentService) ListMentions(ctx context.Context, req *pb.ListMentionsReq) (*pb.ListMentionsRes, error) {
+	u.Log.Info("ListMentions rpc method started")
+	if req.UserId == "" {
+		err := status.Error(codes.InvalidArgument, "user_id is required")
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	if req.Limit <= 0 {
+		req.Limit = defaultMentionsLimit
+	}
+	if req.Limit > maxMentionsLimit {
+		req.Limit = maxMentionsLimit
+	}
+	if req.Offset < 0 {
+		req.Offset = 0
+	}
+
+	res, err := u.Repo.ListMentions(ctx, req)
+	if err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	u.Log.Info("ListMentions rpc method finished")
+	return res, nil
+}
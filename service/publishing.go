@@ -0,0 +1,91 @@
+package service
+
+import (
+	pb "Content-Service/genproto/content"
+	"Content-Service/storage/postgres"
+	"context"
+	"database/sql"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// PublishStory publishes a draft or scheduled story now, or schedules it
+// for publish_at.
+func (u *ContentService) PublishStory(ctx context.Context, req *pb.PublishStoryReq) (*pb.PublishStoryRes, error) {
+	u.Log.Info("PublishStory rpc method started")
+	if req.PublishAt != "" {
+		publishAt, err := parsePublishAt(req.PublishAt, time.Now())
+		if err != nil {
+			u.Log.Error(err.Error())
+			return nil, err
+		}
+		req.PublishAt = publishAt
+	}
+	if err := u.requireStoryAuthor(ctx, req.StoryId, req.UserId); err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+
+	res, err := u.Repo.PublishStory(ctx, req)
+	if err == sql.ErrNoRows {
+		u.Log.Error("story is already published")
+		return nil, status.Error(codes.FailedPrecondition, "story is already published")
+	}
+	if err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	if res.Status == postgres.StatusPublished {
+		u.queueFanOut(ctx, postgres.ItemStory, []string{res.StoryId})
+	}
+	u.Log.Info("PublishStory rpc method finished")
+	return res, nil
+}
+
+// resolvePublishing checks the status and publish_at of a new story and
+// fills in the status implied by them: scheduled when only publish_at is
+// set and published when neither is.
+func resolvePublishing(req *pb.CreateStoriesRequest, now time.Time) error {
+	if req.PublishAt != "" {
+		publishAt, err := parsePublishAt(req.PublishAt, now)
+		if err != nil {
+			return err
+		}
+		req.PublishAt = publishAt
+	}
+
+	switch req.Status {
+	case "":
+		req.Status = postgres.StatusPublished
+		if req.PublishAt != "" {
+			req.Status = postgres.StatusScheduled
+		}
+	case postgres.StatusDraft, postgres.StatusPublished:
+		if req.PublishAt != "" {
+			return status.Errorf(codes.InvalidArgument, "publish_at can only be set on scheduled stories, not %s ones", req.Status)
+		}
+	case postgres.StatusScheduled:
+		if req.PublishAt == "" {
+			return status.Error(codes.InvalidArgument, "scheduled stories need a publish_at time")
+		}
+	default:
+		return status.Errorf(codes.InvalidArgument, "status must be one of %s, %s or %s",
+			postgres.StatusDraft, postgres.StatusScheduled, postgres.StatusPublished)
+	}
+	return nil
+}
+
+// parsePublishAt parses an RFC 3339 publish time, requires it to be in the
+// future and returns it normalised to UTC.
+func parsePublishAt(value string, now time.Time) (string, error) {
+	publishAt, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return "", status.Errorf(codes.InvalidArgument, "publish_at %q is not an RFC 3339 timestamp", value)
+	}
+	if !publishAt.After(now) {
+		return "", status.Error(codes.InvalidArgument, "publish_at must be in the future")
+	}
+	return publishAt.UTC().Format(time.RFC3339), nil
+}
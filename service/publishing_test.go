@@ -0,0 +1,46 @@
+package service
+
+import (
+	pb "Content-Service/genproto/content"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestResolvePublishing(t *testing.T) {
+	now := time.Date(2024, 8, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		status        string
+		publishAt     string
+		want          codes.Code
+		wantStatus    string
+		wantPublishAt string
+	}{
+		{"default publishes", "", "", codes.OK, "published", ""},
+		{"publish_at implies scheduled", "", "2024-08-02T09:00:00+05:00", codes.OK, "scheduled", "2024-08-02T04:00:00Z"},
+		{"draft", "draft", "", codes.OK, "draft", ""},
+		{"explicit schedule", "scheduled", "2024-08-01T13:00:00Z", codes.OK, "scheduled", "2024-08-01T13:00:00Z"},
+		{"schedule without time", "scheduled", "", codes.InvalidArgument, "", ""},
+		{"draft with time", "draft", "2024-08-01T13:00:00Z", codes.InvalidArgument, "", ""},
+		{"time in the past", "scheduled", "2024-08-01T11:00:00Z", codes.InvalidArgument, "", ""},
+		{"bad time", "", "tomorrow", codes.InvalidArgument, "", ""},
+		{"unknown status", "archived", "", codes.InvalidArgument, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &pb.CreateStoriesRequest{Status: tt.status, PublishAt: tt.publishAt}
+			err := resolvePublishing(req, now)
+			if got := status.Code(err); got != tt.want {
+				t.Fatalf("resolvePublishing() code = %v, want %v", got, tt.want)
+			}
+			if err == nil && (req.Status != tt.wantStatus || req.PublishAt != tt.wantPublishAt) {
+				t.Errorf("resolvePublishing() = %q %q, want %q %q", req.Status, req.PublishAt, tt.wantStatus, tt.wantPublishAt)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	pb "Content-Service/genproto/content"
+	"Content-Service/storage/postgres"
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultRelatedLimit = 5
+	maxRelatedLimit     = 20
+
+	// relatedCacheSize is how many related stories are ranked and cached
+	// per story, so any limit up to it is served from one cached list with
+	// room for stories that have since gone.
+	relatedCacheSize = 50
+)
+
+// GetRelatedStories recommends other public stories for a story the viewer
+// can see, ranked by shared tags, destination or location, author and text
+// similarity. Rankings are cached per story until its tags, or the tags of
+// a story sharing them, change.
+func (u *ContentService) GetRelatedStories(ctx context.Context, req *pb.GetRelatedStoriesReq) (*pb.GetRelatedStoriesRes, error) {
+	u.Log.Info("GetRelatedStories rpc method started")
+	if req.StoryId == "" {
+		err := status.Error(codes.InvalidArgument, "story_id is required")
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	if err := u.checkAccess(ctx, postgres.ItemStory, req.StoryId, req.ViewerId, req.ShareToken); err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	if req.Limit <= 0 {
+		req.Limit = defaultRelatedLimit
+	}
+	if req.Limit > maxRelatedLimit {
+		req.Limit = maxRelatedLimit
+	}
+
+	ids, err := u.relatedStoryIDs(ctx, req.StoryId)
+	if err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	stories, err := u.Repo.GetPublicStories(ctx, ids, req.Limit)
+	if err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	u.Log.Info("GetRelatedStories rpc method finished")
+	return &pb.GetRelatedStoriesRes{Stories: stories}, nil
+}
+
+// relatedStoryIDs returns the cached ranking of a story, or ranks and caches
+// it. Cache failures are only logged.
+func (u *ContentService) relatedStoryIDs(ctx context.Context, storyID string) ([]string, error) {
+	ids, hit, err := u.Related.Get(ctx, storyID)
+	if err != nil {
+		u.Log.Error(err.Error())
+	}
+	if hit {
+		return ids, nil
+	}
+
+	ids, err = u.Repo.RelatedStoryIDs(ctx, storyID, relatedCacheSize)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := u.Repo.StoryTags(ctx, storyID)
+	if err == nil {
+		err = u.Related.Set(ctx, storyID, tags, ids)
+	}
+	if err != nil {
+		u.Log.Error(err.Error())
+	}
+	return ids, nil
+}
+
+// invalidateRelated drops the cached rankings a change of a story's tags
+// from old to current can affect. Failures are only logged; the cache
+// expires on its own.
+func (u *ContentService) invalidateRelated(ctx context.Context, storyID string, old, current []string) {
+	added, removed := tagChanges(old, current)
+	if len(added) == 0 && len(removed) == 0 {
+		return
+	}
+	if err := u.Related.Invalidate(ctx, storyID, append(added, removed...)); err != nil {
+		u.Log.Error(err.Error())
+	}
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	pb "Content-Service/genproto/content"
+	"context"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestRelatedStoriesCacheInvalidation(t *testing.T) {
+	u := newTestService(t)
+	ctx := context.Background()
+	author, other := createTestUser(t, u), createTestUser(t, u)
+	tag := "bukhara" + strconv.FormatInt(time.Now().UnixNano(), 36)
+
+	create := func(userID, title string) *pb.CreateStoriesResponse {
+		t.Helper()
+		story, err := u.CreateStories(ctx, &pb.CreateStoriesRequest{Title: title, Content: title, UserId: userID, Tags: []string{tag}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return story
+	}
+	target := create(author, "Old Bukhara")
+	related := create(other, "Lyabi-Hauz")
+
+	rank := func() []*pb.Stories {
+		t.Helper()
+		res, err := u.GetRelatedStories(ctx, &pb.GetRelatedStoriesReq{StoryId: target.Id})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res.Stories
+	}
+	cached := func() bool {
+		t.Helper()
+		_, hit, err := u.Related.Get(ctx, target.Id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return hit
+	}
+
+	if stories := rank(); len(stories) == 0 || stories[0].StoryId != related.Id {
+		t.Fatalf("related stories = %v, want %s first", stories, related.Id)
+	}
+	if !cached() {
+		t.Fatal("ranking was not cached")
+	}
+	create(other, "Chor Minor")
+	if cached() {
+		t.Error("creating a story with the same tag kept the cached ranking")
+	}
+
+	rank()
+	_, err := u.UpdateStories(ctx, &pb.UpdateStoriesReq{Id: related.Id, UserId: other, RemoveTags: []string{tag}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cached() {
+		t.Error("removing the tag from a related story kept the cached ranking")
+	}
+
+	revisions, err := u.ListStoryRevisions(ctx, &pb.ListStoryRevisionsReq{StoryId: related.Id, UserId: other})
+	if err != nil || len(revisions.Revisions) == 0 {
+		t.Fatalf("ListStoryRevisions = %v, %v", revisions, err)
+	}
+	rank()
+	_, err = u.RevertStory(ctx, &pb.RevertStoryReq{StoryId: related.Id, RevisionId: revisions.Revisions[0].Id, UserId: other})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cached() {
+		t.Error("restoring the tag by a revert kept the cached ranking")
+	}
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	pb "Content-Service/genproto/content"
+	"Content-Service/help"
+	"Content-Service/storage/postgres"
+	"context"
+	"database/sql"
+	"slices"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+const (
+	defaultRevisionLimit = 20
+	maxRevisionLimit     = 100
+)
+
+func (u *ContentService) ListStoryRevisions(ctx context.Context, req *pb.ListStoryRevisionsReq) (*pb.ListStoryRevisionsRes, error) {
+	u.Log.Info("ListStoryRevisions rpc method started")
+	if err := u.requireStoryAuthor(ctx, req.StoryId, req.UserId); err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	if req.Limit <= 0 {
+		req.Limit = defaultRevisionLimit
+	}
+	if req.Limit > maxRevisionLimit {
+		req.Limit = maxRevisionLimit
+	}
+	if req.Offset < 0 {
+		req.Offset = 0
+	}
+
+	res, err := u.Repo.ListStoryRevisions(ctx, req)
+	if err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	u.Log.Info("ListStoryRevisions rpc method finished")
+	return res, nil
+}
+
+// GetStoryRevision returns a revision together with a line-level diff from
+// the revision to the current story.
+func (u *ContentService) GetStoryRevision(ctx context.Context, req *pb.GetStoryRevisionReq) (*pb.GetStoryRevisionRes, error) {
+	u.Log.Info("GetStoryRevision rpc method started")
+	if err := u.requireStoryAuthor(ctx, req.StoryId, req.UserId); err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	revision, err := u.getStoryRevision(ctx, req.StoryId, req.RevisionId)
+	if err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	current, err := u.Repo.GetStoryById(ctx, &pb.StoryId{Id: req.StoryId})
+	if err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+
+	added, removed := tagChanges(revision.Tags, current.Tags)
+	u.Log.Info("GetStoryRevision rpc method finished")
+	return &pb.GetStoryRevisionRes{
+		Revision:       revision,
+		TitleDiff:      toDiffLines(help.DiffLines(revision.Title, current.Title)),
+		ContentDiff:    toDiffLines(help.DiffLines(revision.Content, current.Content)),
+		LocationDiff:   toDiffLines(help.DiffLines(revision.Location, current.Location)),
+		TagsAdded:      added,
+		TagsRemoved:    removed,
+		CurrentVersion: current.Version,
+	}, nil
+}
+
+// RevertStory restores the title, content, content format, location and tags
+// of a revision as a new edit, so the state being replaced becomes a
+// revision itself.
+func (u *ContentService) RevertStory(ctx context.Context, req *pb.RevertStoryReq) (*pb.UpdateStoriesRes, error) {
+	u.Log.Info("RevertStory rpc method started")
+	if err := u.requireStoryAuthor(ctx, req.StoryId, req.UserId); err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	revision, err := u.getStoryRevision(ctx, req.StoryId, req.RevisionId)
+	if err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+
+	oldTags, err := u.Repo.StoryTags(ctx, req.StoryId)
+	if err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	res, err := u.Repo.UpdateStory(ctx, &pb.UpdateStoriesReq{
+		Id:              req.StoryId,
+		UserId:          req.UserId,
+		ExpectedVersion: req.ExpectedVersion,
+		Title:           revision.Title,
+		Content:         revision.Content,
+		ContentFormat:   revision.ContentFormat,
+		Location:        revision.Location,
+		Tags:            revision.Tags,
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{
+			postgres.StoryPathTitle, postgres.StoryPathContent, postgres.StoryPathContentFormat,
+			postgres.StoryPathLocation, postgres.StoryPathTags,
+		}},
+	})
+	if err != nil {
+		u.Log.Error(err.Error())
+		return nil, versionError(err)
+	}
+	u.invalidateRelated(ctx, req.StoryId, oldTags, res.Tags)
+	u.Log.Info("RevertStory rpc method finished")
+	return res, nil
+}
+
+func (u *ContentService) getStoryRevision(ctx context.Context, storyID, revisionID string) (*pb.StoryRevision, error) {
+	revision, err := u.Repo.GetStoryRevision(ctx, storyID, revisionID)
+	if err == sql.ErrNoRows {
+		return nil, status.Error(codes.NotFound, "revision not found")
+	}
+	return revision, err
+}
+
+// requireStoryAuthor returns NotFound when the story does not exist and
+// PermissionDenied unless userID is its author.
+func (u *ContentService) requireStoryAuthor(ctx context.Context, storyID, userID string) error {
+	authorID, _, err := u.Repo.ItemOwner(ctx, postgres.ItemStory, storyID)
+	if err == sql.ErrNoRows {
+		return status.Error(codes.NotFound, "story not found")
+	}
+	if err != nil {
+		return err
+	}
+	if userID == "" || authorID != userID {
+		return status.Error(codes.PermissionDenied, "only the author can change this story")
+	}
+	return nil
+}
+
+func toDiffLines(diff []help.DiffLine) []*pb.DiffLine {
+	out := make([]*pb.DiffLine, 0, len(diff))
+	for _, line := range diff {
+		out = append(out, &pb.DiffLine{Op: line.Op, Text: line.Text})
+	}
+	return out
+}
+
+// tagChanges returns the tags present in current but not in old, and the
+// other way round.
+func tagChanges(old, current []string) (added, removed []string) {
+	for _, tag := range current {
+		if !slices.Contains(old, tag) {
+			added = append(added, tag)
+		}
+	}
+	for _, tag := range old {
+		if !slices.Contains(current, tag) {
+			removed = append(removed, tag)
+		}
+	}
+	return added, removed
+}
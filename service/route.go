@@ -0,0 +1,67 @@
+package service
+
+import (
+	"Content-Service/config"
+	pb "Content-Service/genproto/content"
+	"Content-Service/help"
+	"math"
+)
+
+// groundDetourFactor approximates how much longer road and rail routes are
+// than the great-circle distance between two places.
+const groundDetourFactor = 1.3
+
+type travelMode struct {
+	name         string
+	speedKmh     float64
+	detourFactor float64
+}
+
+func travelModes(cfg config.RouteConfig) []travelMode {
+	return []travelMode{
+		{name: "flight", speedKmh: cfg.FLIGHT_SPEED_KMH, detourFactor: 1},
+		{name: "train", speedKmh: cfg.TRAIN_SPEED_KMH, detourFactor: groundDetourFactor},
+		{name: "car", speedKmh: cfg.CAR_SPEED_KMH, detourFactor: groundDetourFactor},
+	}
+}
+
+// buildRoute returns the legs between consecutive destinations and the total
+// distance of the legs whose endpoints both have coordinates.
+func buildRoute(destinations []*pb.Destination, modes []travelMode) ([]*pb.RouteLeg, float64) {
+	var (
+		legs  []*pb.RouteLeg
+		total float64
+	)
+
+	for i := 1; i < len(destinations); i++ {
+		from, to := destinations[i-1], destinations[i]
+		leg := &pb.RouteLeg{
+			From: from.Name,
+			To:   to.Name,
+		}
+
+		if from.Latitude != nil && from.Longitude != nil && to.Latitude != nil && to.Longitude != nil {
+			distance := help.Haversine(*from.Latitude, *from.Longitude, *to.Latitude, *to.Longitude)
+			leg.HasCoordinates = true
+			leg.DistanceKm = roundTenth(distance)
+			for _, mode := range modes {
+				if mode.speedKmh <= 0 {
+					continue
+				}
+				leg.Estimates = append(leg.Estimates, &pb.TravelEstimate{
+					Mode:  mode.name,
+					Hours: roundTenth(distance * mode.detourFactor / mode.speedKmh),
+				})
+			}
+			total += distance
+		}
+
+		legs = append(legs, leg)
+	}
+
+	return legs, roundTenth(total)
+}
+
+func roundTenth(v float64) float64 {
+	return math.Round(v*10) / 10
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	pb "Content-Service/genproto/content"
+	"testing"
+)
+
+func TestBuildRoute(t *testing.T) {
+	lat := func(v float64) *float64 { return &v }
+
+	destinations := []*pb.Destination{
+		{Name: "Tashkent", Latitude: lat(41.2995), Longitude: lat(69.2401)},
+		{Name: "Samarkand", Latitude: lat(39.6542), Longitude: lat(66.9597)},
+		{Name: "Nowhere"},
+		{Name: "Bukhara", Latitude: lat(39.7747), Longitude: lat(64.4286)},
+	}
+	modes := []travelMode{
+		{name: "flight", speedKmh: 500, detourFactor: 1},
+		{name: "walk", speedKmh: 0, detourFactor: 1},
+	}
+
+	legs, total := buildRoute(destinations, modes)
+	if len(legs) != 3 {
+		t.Fatalf("got %d legs, want 3", len(legs))
+	}
+
+	first := legs[0]
+	if first.From != "Tashkent" || first.To != "Samarkand" || !first.HasCoordinates {
+		t.Errorf("first leg = %+v, want Tashkent to Samarkand with coordinates", first)
+	}
+	if len(first.Estimates) != 1 || first.Estimates[0].Mode != "flight" {
+		t.Errorf("first leg estimates = %v, want only flight", first.Estimates)
+	}
+	if legs[1].HasCoordinates || legs[2].HasCoordinates {
+		t.Errorf("legs touching a destination without coordinates should not have distances")
+	}
+	if total != first.DistanceKm {
+		t.Errorf("total = %v, want %v", total, first.DistanceKm)
+	}
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	pb "Content-Service/genproto/content"
+	"Content-Service/help"
+	"Content-Service/storage/postgres"
+	"context"
+	"sort"
+)
+
+func (u *ContentService) GetItinerarySchedule(ctx context.Context, req *pb.GetItineraryScheduleReq) (*pb.GetItineraryScheduleRes, error) {
+	u.Log.Info("GetItinerarySchedule rpc method started")
+	if err := u.checkAccess(ctx, postgres.ItemItinerary, req.ItineraryId, req.ViewerId, req.ShareToken); err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	itinerary, err := u.Repo.GetItinerariesById(ctx, &pb.GetItinerariesByIdReq{Id: req.ItineraryId})
+	if err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+
+	res, err := buildSchedule(itinerary)
+	if err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	u.Log.Info("GetItinerarySchedule rpc method finished")
+	return res, nil
+}
+
+// buildSchedule lays out the activities of an itinerary per calendar date.
+// An activity with a day index falls on that day of its destination's stay;
+// activities without one are returned as unscheduled. Within a day, timed
+// activities come first in start-time order followed by untimed ones in
+// their saved order, and timed activities whose intervals intersect are
+// flagged as conflicting.
+func buildSchedule(itinerary *pb.GetItinerariesByIdRes) (*pb.GetItineraryScheduleRes, error) {
+	res := &pb.GetItineraryScheduleRes{
+		ItineraryId: itinerary.Id,
+		Title:       itinerary.Title,
+	}
+
+	tripStart, err := help.ParseDate(itinerary.StartDate)
+	if err != nil {
+		return nil, err
+	}
+	tripEnd, err := help.ParseDate(itinerary.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	days := make(map[string]*pb.ScheduleDay)
+	dayFor := func(date string) *pb.ScheduleDay {
+		d, ok := days[date]
+		if !ok {
+			d = &pb.ScheduleDay{Date: date}
+			days[date] = d
+		}
+		return d
+	}
+	for d := tripStart; !d.After(tripEnd); d = d.AddDate(0, 0, 1) {
+		dayFor(help.FormatDate(d))
+	}
+
+	for _, dest := range itinerary.Destination {
+		start, err := help.ParseDate(dest.StartDate)
+		if err != nil {
+			return nil, err
+		}
+		end, err := help.ParseDate(dest.EndDate)
+		if err != nil {
+			return nil, err
+		}
+		for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+			day := dayFor(help.FormatDate(d))
+			day.Destinations = append(day.Destinations, dest.Name)
+		}
+
+		for _, activity := range dest.Activities {
+			scheduled := &pb.ScheduledActivity{
+				Id:              activity.Id,
+				Destination:     dest.Name,
+				Text:            activity.Text,
+				StartTime:       activity.StartTime,
+				DurationMinutes: activity.DurationMinutes,
+				Position:        activity.Position,
+				Cost:            activity.Cost,
+				Category:        activity.Category,
+			}
+			if activity.StartTime != "" && activity.DurationMinutes > 0 {
+				if minutes, err := help.ParseClock(activity.StartTime); err == nil {
+					scheduled.EndTime = help.FormatClock(minutes + int(activity.DurationMinutes))
+				}
+			}
+
+			if activity.DayIndex <= 0 {
+				res.Unscheduled = append(res.Unscheduled, scheduled)
+				continue
+			}
+			date := start.AddDate(0, 0, int(activity.DayIndex)-1)
+			day := dayFor(help.FormatDate(date))
+			day.Activities = append(day.Activities, scheduled)
+		}
+	}
+
+	for _, day := range days {
+		sortActivities(day.Activities)
+		day.HasConflicts = flagConflicts(day.Activities)
+		res.Days = append(res.Days, day)
+	}
+	sort.Slice(res.Days, func(i, j int) bool { return res.Days[i].Date < res.Days[j].Date })
+
+	return res, nil
+}
+
+func sortActivities(activities []*pb.ScheduledActivity) {
+	sort.SliceStable(activities, func(i, j int) bool {
+		a, b := activities[i], activities[j]
+		if (a.StartTime == "") != (b.StartTime == "") {
+			return a.StartTime != ""
+		}
+		if a.StartTime != b.StartTime {
+			return a.StartTime < b.StartTime
+		}
+		return a.Position < b.Position
+	})
+}
+
+// flagConflicts records, for each timed activity, the ids of the other timed
+// activities it overlaps and reports whether any overlap was found. Two
+// activities starting at the same time always conflict.
+func flagConflicts(activities []*pb.ScheduledActivity) bool {
+	type interval struct {
+		activity   *pb.ScheduledActivity
+		start, end int
+	}
+
+	var timed []interval
+	for _, a := range activities {
+		start, err := help.ParseClock(a.StartTime)
+		if err != nil {
+			continue
+		}
+		timed = append(timed, interval{activity: a, start: start, end: start + int(a.DurationMinutes)})
+	}
+
+	conflicts := false
+	for i := range timed {
+		for j := i + 1; j < len(timed); j++ {
+			a, b := timed[i], timed[j]
+			if a.start == b.start || (a.start < b.end && b.start < a.end) {
+				a.activity.ConflictsWith = append(a.activity.ConflictsWith, b.activity.Id)
+				b.activity.ConflictsWith = append(b.activity.ConflictsWith, a.activity.Id)
+				conflicts = true
+			}
+		}
+	}
+	return conflicts
+}
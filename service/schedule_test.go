@@ -0,0 +1,71 @@
+package service
+
+import (
+	pb "Content-Service/genproto/content"
+	"testing"
+)
+
+func TestBuildSchedule(t *testing.T) {
+	itinerary := &pb.GetItinerariesByIdRes{
+		Id:        "it1",
+		Title:     "Uzbekistan",
+		StartDate: "2024-08-01T00:00:00Z",
+		EndDate:   "2024-08-03T00:00:00Z",
+		Destination: []*pb.Destination{
+			{
+				Name:      "Tashkent",
+				StartDate: "2024-08-01T00:00:00Z",
+				EndDate:   "2024-08-02T00:00:00Z",
+				Activities: []*pb.Activities{
+					{Id: "a1", Text: "Museum", DayIndex: 1, StartTime: "10:00", DurationMinutes: 120, Position: 1},
+					{Id: "a2", Text: "Lunch", DayIndex: 1, StartTime: "11:30", DurationMinutes: 60, Position: 2},
+					{Id: "a3", Text: "Walk", DayIndex: 1, Position: 3},
+					{Id: "a4", Text: "Dinner", DayIndex: 2, StartTime: "19:00", DurationMinutes: 90},
+					{Id: "a5", Text: "Souvenirs"},
+				},
+			},
+			{
+				Name:      "Samarkand",
+				StartDate: "2024-08-02T00:00:00Z",
+				EndDate:   "2024-08-03T00:00:00Z",
+				Activities: []*pb.Activities{
+					{Id: "a6", Text: "Registan", DayIndex: 1, StartTime: "08:00", DurationMinutes: 60},
+				},
+			},
+		},
+	}
+
+	res, err := buildSchedule(itinerary)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Days) != 3 {
+		t.Fatalf("got %d days, want 3", len(res.Days))
+	}
+
+	first := res.Days[0]
+	if first.Date != "2024-08-01" || !first.HasConflicts || len(first.Activities) != 3 {
+		t.Fatalf("first day = %+v, want three activities with a conflict", first)
+	}
+	if first.Activities[0].Id != "a1" || first.Activities[0].EndTime != "12:00" {
+		t.Errorf("first activity = %+v, want a1 ending 12:00", first.Activities[0])
+	}
+	if got := first.Activities[1].ConflictsWith; len(got) != 1 || got[0] != "a1" {
+		t.Errorf("lunch conflicts with %v, want [a1]", got)
+	}
+	if first.Activities[2].Id != "a3" || len(first.Activities[2].ConflictsWith) != 0 {
+		t.Errorf("untimed activity = %+v, want a3 last without conflicts", first.Activities[2])
+	}
+
+	second := res.Days[1]
+	if len(second.Destinations) != 2 || second.HasConflicts {
+		t.Errorf("second day = %+v, want two destinations and no conflicts", second)
+	}
+	if len(second.Activities) != 2 || second.Activities[0].Id != "a6" {
+		t.Errorf("second day activities = %v, want Registan first", second.Activities)
+	}
+
+	if len(res.Unscheduled) != 1 || res.Unscheduled[0].Id != "a5" {
+		t.Errorf("unscheduled = %v, want [a5]", res.Unscheduled)
+	}
+}
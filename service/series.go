@@ -0,0 +1,97 @@
+package service
+
+import (
+	pb "Content-Service/genproto/content"
+	"Content-Service/storage/postgres"
+	"context"
+	"database/sql"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (u *ContentService) CreateSeries(ctx context.Context, req *pb.CreateSeriesReq) (*pb.Series, error) {
+	u.Log.Info("CreateSeries rpc method started")
+	req.Title = strings.TrimSpace(req.Title)
+	if req.Title == "" {
+		err := status.Error(codes.InvalidArgument, "title is required")
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+
+	res, err := u.Repo.CreateSeries(ctx, req)
+	if err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	u.Log.Info("CreateSeries rpc method finished")
+	return res, nil
+}
+
+// AddStoryToSeries adds one of the author's stories to one of their series.
+// A position of 0 appends the story.
+func (u *ContentService) AddStoryToSeries(ctx context.Context, req *pb.AddStoryToSeriesReq) (*pb.Series, error) {
+	u.Log.Info("AddStoryToSeries rpc method started")
+	if req.Position < 0 {
+		err := status.Error(codes.InvalidArgument, "position must not be negative")
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	if err := u.requireSeriesAuthor(ctx, req.SeriesId, req.UserId); err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	if err := u.requireStoryAuthor(ctx, req.StoryId, req.UserId); err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+
+	res, err := u.Repo.AddStoryToSeries(ctx, req)
+	if err == postgres.ErrStoryInSeries {
+		u.Log.Error(err.Error())
+		return nil, status.Error(codes.AlreadyExists, err.Error())
+	}
+	if err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	u.Log.Info("AddStoryToSeries rpc method finished")
+	return res, nil
+}
+
+func (u *ContentService) ReorderSeries(ctx context.Context, req *pb.ReorderSeriesReq) (*pb.Series, error) {
+	u.Log.Info("ReorderSeries rpc method started")
+	if err := u.requireSeriesAuthor(ctx, req.SeriesId, req.UserId); err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+
+	res, err := u.Repo.ReorderSeries(ctx, req)
+	if err == postgres.ErrSeriesOrder {
+		u.Log.Error(err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	u.Log.Info("ReorderSeries rpc method finished")
+	return res, nil
+}
+
+// requireSeriesAuthor returns NotFound when the series does not exist and
+// PermissionDenied unless userID is its author.
+func (u *ContentService) requireSeriesAuthor(ctx context.Context, seriesID, userID string) error {
+	authorID, err := u.Repo.SeriesAuthor(ctx, seriesID)
+	if err == sql.ErrNoRows {
+		return status.Error(codes.NotFound, "series not found")
+	}
+	if err != nil {
+		return err
+	}
+	if userID == "" || authorID != userID {
+		return status.Error(codes.PermissionDenied, "only the author can change this series")
+	}
+	return nil
+}
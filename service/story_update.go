@@ -0,0 +1,71 @@
+package service
+
+import (
+	pb "Content-Service/genproto/content"
+	"Content-Service/help"
+	"Content-Service/storage/postgres"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// validateStoryUpdate checks the update mask of an UpdateStories request and
+// the values of the fields it selects.
+func validateStoryUpdate(req *pb.UpdateStoriesReq) error {
+	paths := req.GetUpdateMask().GetPaths()
+	for _, path := range paths {
+		if !slices.Contains(postgres.StoryPaths, path) {
+			return status.Errorf(codes.InvalidArgument, "unknown update_mask path %q, expected one of %s",
+				path, strings.Join(postgres.StoryPaths, ", "))
+		}
+	}
+	if len(paths) == 0 {
+		paths = postgres.PopulatedStoryPaths(req)
+	}
+	if len(paths) == 0 && len(req.AddTags) == 0 && len(req.RemoveTags) == 0 {
+		return status.Error(codes.InvalidArgument, "nothing to update")
+	}
+
+	if slices.Contains(paths, postgres.StoryPathTitle) && strings.TrimSpace(req.Title) == "" {
+		return status.Error(codes.InvalidArgument, "title must not be empty")
+	}
+	if slices.Contains(paths, postgres.StoryPathVisibility) {
+		if req.Visibility == "" {
+			return status.Error(codes.InvalidArgument, "visibility must not be empty")
+		}
+		if err := validateVisibility(req.Visibility); err != nil {
+			return err
+		}
+	}
+
+	if slices.Contains(paths, postgres.StoryPathContentFormat) {
+		if req.ContentFormat == "" {
+			return status.Error(codes.InvalidArgument, "content_format must not be empty")
+		}
+		if err := validateContentFormat(req.ContentFormat); err != nil {
+			return err
+		}
+	}
+
+	hasLatitude := slices.Contains(paths, postgres.StoryPathLatitude)
+	hasLongitude := slices.Contains(paths, postgres.StoryPathLongitude)
+	if hasLatitude != hasLongitude {
+		return status.Error(codes.InvalidArgument, "latitude and longitude must be updated together")
+	}
+	if hasLatitude {
+		if err := help.ValidateCoordinates(req.Latitude, req.Longitude); err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	return nil
+}
+
+func validateContentFormat(format string) error {
+	if format != "" && !postgres.ValidContentFormat(format) {
+		return status.Errorf(codes.InvalidArgument, "content_format must be %s or %s",
+			postgres.ContentFormatPlain, postgres.ContentFormatMarkdown)
+	}
+	return nil
+}
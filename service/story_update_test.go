@@ -0,0 +1,45 @@
+package service
+
+import (
+	pb "Content-Service/genproto/content"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestValidateStoryUpdate(t *testing.T) {
+	lat, lng := 41.3, 69.2
+	mask := func(paths ...string) *fieldmaskpb.FieldMask { return &fieldmaskpb.FieldMask{Paths: paths} }
+
+	tests := []struct {
+		name string
+		req  *pb.UpdateStoriesReq
+		want codes.Code
+	}{
+		{"populated fields", &pb.UpdateStoriesReq{Id: "s", Title: "New title"}, codes.OK},
+		{"tag changes only", &pb.UpdateStoriesReq{Id: "s", AddTags: []string{"food"}}, codes.OK},
+		{"masked location clear", &pb.UpdateStoriesReq{Id: "s", UpdateMask: mask("location", "tags")}, codes.OK},
+		{"masked coordinates", &pb.UpdateStoriesReq{Id: "s", UpdateMask: mask("latitude", "longitude"), Latitude: &lat, Longitude: &lng}, codes.OK},
+		{"cleared coordinates", &pb.UpdateStoriesReq{Id: "s", UpdateMask: mask("latitude", "longitude")}, codes.OK},
+		{"nothing to update", &pb.UpdateStoriesReq{Id: "s"}, codes.InvalidArgument},
+		{"unknown path", &pb.UpdateStoriesReq{Id: "s", UpdateMask: mask("author_id")}, codes.InvalidArgument},
+		{"empty masked title", &pb.UpdateStoriesReq{Id: "s", UpdateMask: mask("title")}, codes.InvalidArgument},
+		{"empty masked visibility", &pb.UpdateStoriesReq{Id: "s", UpdateMask: mask("visibility")}, codes.InvalidArgument},
+		{"bad visibility", &pb.UpdateStoriesReq{Id: "s", Visibility: "friends"}, codes.InvalidArgument},
+		{"latitude alone", &pb.UpdateStoriesReq{Id: "s", UpdateMask: mask("latitude"), Latitude: &lat}, codes.InvalidArgument},
+		{"markdown format", &pb.UpdateStoriesReq{Id: "s", ContentFormat: "markdown"}, codes.OK},
+		{"empty masked format", &pb.UpdateStoriesReq{Id: "s", UpdateMask: mask("content_format")}, codes.InvalidArgument},
+		{"bad format", &pb.UpdateStoriesReq{Id: "s", ContentFormat: "html"}, codes.InvalidArgument},
+		{"partial pair", &pb.UpdateStoriesReq{Id: "s", UpdateMask: mask("latitude", "longitude"), Latitude: &lat}, codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := status.Code(validateStoryUpdate(tt.req)); got != tt.want {
+				t.Errorf("validateStoryUpdate() code = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
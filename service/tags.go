@@ -0,0 +1,71 @@
+package service
+
+import (
+	pb "Content-Service/genproto/content"
+	"context"
+)
+
+const (
+	defaultTagsLimit           = 20
+	maxTagsLimit               = 100
+	defaultTrendingWindowHours = 24
+	maxTrendingWindowHours     = 7 * 24
+	defaultTrendingBaseline    = 7
+	maxTrendingBaseline        = 90
+	defaultTrendingLimit       = 10
+	maxTrendingLimit           = 50
+)
+
+func (u *ContentService) ListTags(ctx context.Context, req *pb.ListTagsReq) (*pb.ListTagsRes, error) {
+	u.Log.Info("ListTags rpc method started")
+	if req.Limit <= 0 {
+		req.Limit = defaultTagsLimit
+	}
+	if req.Limit > maxTagsLimit {
+		req.Limit = maxTagsLimit
+	}
+	if req.Offset < 0 {
+		req.Offset = 0
+	}
+
+	res, err := u.Repo.ListTags(ctx, req)
+	if err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	u.Log.Info("ListTags rpc method finished")
+	return res, nil
+}
+
+// GetTrendingTags ranks tags by their growth over a recent window compared
+// with a longer baseline period before it.
+func (u *ContentService) GetTrendingTags(ctx context.Context, req *pb.GetTrendingTagsReq) (*pb.GetTrendingTagsRes, error) {
+	u.Log.Info("GetTrendingTags rpc method started")
+	windowHours := clamp(req.WindowHours, defaultTrendingWindowHours, maxTrendingWindowHours)
+	baselineDays := clamp(req.BaselineDays, defaultTrendingBaseline, maxTrendingBaseline)
+	limit := clamp(req.Limit, defaultTrendingLimit, maxTrendingLimit)
+
+	tags, err := u.Repo.GetTrendingTags(ctx, windowHours, baselineDays, limit)
+	if err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	u.Log.Info("GetTrendingTags rpc method finished")
+	return &pb.GetTrendingTagsRes{
+		Tags:         tags,
+		WindowHours:  windowHours,
+		BaselineDays: baselineDays,
+	}, nil
+}
+
+// clamp returns fallback for a missing or negative value and caps it at
+// upper.
+func clamp(value, fallback, upper int64) int64 {
+	if value <= 0 {
+		return fallback
+	}
+	if value > upper {
+		return upper
+	}
+	return value
+}
@@ -0,0 +1,19 @@
+package service
+
+import "testing"
+
+func TestClamp(t *testing.T) {
+	tests := []struct {
+		value, want int64
+	}{
+		{0, 24},
+		{-3, 24},
+		{12, 12},
+		{1000, 168},
+	}
+	for _, tt := range tests {
+		if got := clamp(tt.value, 24, 168); got != tt.want {
+			t.Errorf("clamp(%d) = %d, want %d", tt.value, got, tt.want)
+		}
+	}
+}
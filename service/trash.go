@@ -0,0 +1,83 @@
+package service
+
+import (
+	pb "Content-Service/genproto/content"
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultTrashLimit = 20
+	maxTrashLimit     = 100
+)
+
+func (u *ContentService) RestoreStory(ctx context.Context, req *pb.RestoreStoryReq) (*pb.GetStoryRes, error) {
+	u.Log.Info("RestoreStory rpc method started")
+	restored, err := u.Repo.RestoreStory(ctx, req, u.trashRetentionSeconds())
+	if err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	if !restored {
+		u.Log.Error("story not found in trash")
+		return nil, status.Error(codes.NotFound, "story not found in trash")
+	}
+
+	res, err := u.Repo.GetStoryById(ctx, &pb.StoryId{Id: req.StoryId})
+	if err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	u.Log.Info("RestoreStory rpc method finished")
+	return res, nil
+}
+
+func (u *ContentService) RestoreItinerary(ctx context.Context, req *pb.RestoreItineraryReq) (*pb.GetItinerariesByIdRes, error) {
+	u.Log.Info("RestoreItinerary rpc method started")
+	restored, err := u.Repo.RestoreItinerary(ctx, req, u.trashRetentionSeconds())
+	if err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	if !restored {
+		u.Log.Error("itinerary not found in trash")
+		return nil, status.Error(codes.NotFound, "itinerary not found in trash")
+	}
+
+	res, err := u.Repo.GetItinerariesById(ctx, &pb.GetItinerariesByIdReq{Id: req.ItineraryId})
+	if err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	u.Log.Info("RestoreItinerary rpc method finished")
+	return res, nil
+}
+
+func (u *ContentService) ListTrash(ctx context.Context, req *pb.ListTrashReq) (*pb.ListTrashRes, error) {
+	u.Log.Info("ListTrash rpc method started")
+	if req.Limit <= 0 {
+		req.Limit = defaultTrashLimit
+	}
+	if req.Limit > maxTrashLimit {
+		req.Limit = maxTrashLimit
+	}
+	if req.Offset < 0 {
+		req.Offset = 0
+	}
+
+	res, err := u.Repo.ListTrash(ctx, req, u.trashRetentionSeconds())
+	if err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	u.Log.Info("ListTrash rpc method finished")
+	return res, nil
+}
+
+// trashRetentionSeconds is how long deleted items can be restored before
+// the purger removes them.
+func (u *ContentService) trashRetentionSeconds() int64 {
+	return int64(u.Cfg.Trash.TRASH_RETENTION_DAYS) * 24 * 60 * 60
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"Content-Service/config"
+	pb "Content-Service/genproto/content"
+	"Content-Service/storage/postgres"
+	"context"
+)
+
+const (
+	defaultTrendingStoriesLimit = 20
+	maxTrendingStoriesLimit     = 100
+)
+
+// GetTrendingStories ranks recent stories by engagement decayed over time.
+// Points are read from the Redis trending set, which is seeded from the
+// story counters in Postgres the first time it is used after Redis starts
+// empty; when it is empty or Redis is down the counters are used directly.
+func (u *ContentService) GetTrendingStories(ctx context.Context, req *pb.GetTrendingStoriesReq) (*pb.GetTrendingStoriesRes, error) {
+	u.Log.Info("GetTrendingStories rpc method started")
+	if req.Limit <= 0 {
+		req.Limit = defaultTrendingStoriesLimit
+	}
+	if req.Limit > maxTrendingStoriesLimit {
+		req.Limit = maxTrendingStoriesLimit
+	}
+	if req.Offset < 0 {
+		req.Offset = 0
+	}
+	params := trendingParams(u.Cfg.Trending)
+
+	u.seedTrending(ctx, params)
+	ids, points, err := u.Trending.Points(ctx)
+	if err != nil {
+		u.Log.Error(err.Error())
+	} else if len(ids) > 0 {
+		u.removeStaleTrending(ctx, ids, params.WindowHours)
+	}
+	if len(ids) == 0 {
+		// A nil slice makes the repository fall back to the counters.
+		ids, points = nil, nil
+	}
+
+	res, err := u.Repo.GetTrendingStories(ctx, req, params, ids, points)
+	if err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	u.Log.Info("GetTrendingStories rpc method finished")
+	return res, nil
+}
+
+func trendingParams(cfg config.TrendingConfig) postgres.TrendingParams {
+	return postgres.TrendingParams{
+		Gravity:       cfg.TRENDING_GRAVITY,
+		LikeWeight:    cfg.TRENDING_LIKE_WEIGHT,
+		CommentWeight: cfg.TRENDING_COMMENT_WEIGHT,
+		ViewWeight:    cfg.TRENDING_VIEW_WEIGHT,
+		WindowHours:   cfg.TRENDING_WINDOW_HOURS,
+	}
+}
+
+// addTrendingPoints adds engagement points to a story. Failures are only
+// logged: the trending set is rebuilt from Postgres when it is lost.
+func (u *ContentService) addTrendingPoints(ctx context.Context, storyID string, points float64) {
+	if points == 0 {
+		return
+	}
+	if err := u.Trending.Add(ctx, storyID, points); err != nil {
+		u.Log.Error(err.Error())
+	}
+}
+
+// seedTrending fills the trending set from the counters unless another
+// request already did since Redis started empty. Points added by likes,
+// comments and views before that are kept when higher.
+func (u *ContentService) seedTrending(ctx context.Context, params postgres.TrendingParams) {
+	claimed, err := u.Trending.ClaimSeed(ctx)
+	if err != nil {
+		u.Log.Error(err.Error())
+		return
+	}
+	if !claimed {
+		return
+	}
+
+	ids, points, err := u.Repo.TrendingPoints(ctx, params)
+	if err == nil {
+		err = u.Trending.Seed(ctx, ids, points)
+	}
+	if err != nil {
+		u.Log.Error(err.Error())
+		if err := u.Trending.ReleaseSeed(ctx); err != nil {
+			u.Log.Error(err.Error())
+		}
+	}
+}
+
+// removeStaleTrending drops stories that can no longer trend from the set
+// so it only holds stories inside the trending window.
+func (u *ContentService) removeStaleTrending(ctx context.Context, ids []string, windowHours int64) {
+	stale, err := u.Repo.StaleTrendingStories(ctx, ids, windowHours)
+	if err == nil {
+		err = u.Trending.Remove(ctx, stale)
+	}
+	if err != nil {
+		u.Log.Error(err.Error())
+	}
+}
@@ -0,0 +1,20 @@
+package service
+
+import (
+	"Content-Service/storage/postgres"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// versionError reports a lost optimistic-concurrency race as Aborted so the
+// client knows to re-read the item and retry its edit. Other errors are
+// returned unchanged.
+func versionError(err error) error {
+	var conflict *postgres.VersionConflictError
+	if errors.As(err, &conflict) {
+		return status.Error(codes.Aborted, conflict.Error())
+	}
+	return err
+}
@@ -0,0 +1,24 @@
+package service
+
+import (
+	"Content-Service/storage/postgres"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestVersionError(t *testing.T) {
+	conflict := &postgres.VersionConflictError{Expected: 3, Current: 4}
+	for _, err := range []error{conflict, fmt.Errorf("update failed: %w", conflict)} {
+		if code := status.Code(versionError(err)); code != codes.Aborted {
+			t.Errorf("versionError(%v) code = %v, want Aborted", err, code)
+		}
+	}
+
+	if err := versionError(sql.ErrNoRows); err != sql.ErrNoRows {
+		t.Errorf("versionError(sql.ErrNoRows) = %v, want it unchanged", err)
+	}
+}
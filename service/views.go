@@ -0,0 +1,61 @@
+package service
+
+import (
+	pb "Content-Service/genproto/content"
+	"Content-Service/storage/postgres"
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RecordView counts a view of a story the viewer can read. Each viewer is
+// counted once per story and day, and authors reading their own stories
+// are not counted.
+func (u *ContentService) RecordView(ctx context.Context, req *pb.RecordViewReq) (*pb.Void, error) {
+	u.Log.Info("RecordView rpc method started")
+	if req.ViewerId == "" {
+		err := status.Error(codes.InvalidArgument, "viewer_id is required")
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	if err := u.checkAccess(ctx, postgres.ItemStory, req.StoryId, req.ViewerId, req.ShareToken); err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+
+	authorID, _, err := u.Repo.ItemOwner(ctx, postgres.ItemStory, req.StoryId)
+	if err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	if authorID == req.ViewerId {
+		u.Log.Info("RecordView rpc method finished")
+		return &pb.Void{}, nil
+	}
+
+	first, err := u.Views.Record(ctx, req.StoryId, req.ViewerId, time.Now())
+	if err != nil {
+		u.Log.Error(err.Error())
+		return nil, status.Error(codes.Unavailable, "views cannot be recorded right now")
+	}
+	if first {
+		u.addTrendingPoints(ctx, req.StoryId, u.Cfg.Trending.TRENDING_VIEW_WEIGHT)
+	}
+	u.Log.Info("RecordView rpc method finished")
+	return &pb.Void{}, nil
+}
+
+// recordView counts a view like RecordView but only logs failures, for
+// reads that count views implicitly.
+func (u *ContentService) recordView(ctx context.Context, storyID, viewerID string) {
+	first, err := u.Views.Record(ctx, storyID, viewerID, time.Now())
+	if err != nil {
+		u.Log.Error(err.Error())
+		return
+	}
+	if first {
+		u.addTrendingPoints(ctx, storyID, u.Cfg.Trending.TRENDING_VIEW_WEIGHT)
+	}
+}
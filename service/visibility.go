@@ -0,0 +1,115 @@
+package service
+
+import (
+	pb "Content-Service/genproto/content"
+	"Content-Service/help"
+	"Content-Service/storage/postgres"
+	"context"
+	"database/sql"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (u *ContentService) CreateShareLink(ctx context.Context, req *pb.CreateShareLinkReq) (*pb.ShareLink, error) {
+	u.Log.Info("CreateShareLink rpc method started")
+	if u.Cfg.Share.SHARE_LINK_SECRET == "" {
+		u.Log.Error("share link secret is not configured")
+		return nil, status.Error(codes.FailedPrecondition, "share links are not configured")
+	}
+	if req.ExpiresInSeconds < 0 {
+		u.Log.Error("negative share link expiry")
+		return nil, status.Error(codes.InvalidArgument, "expires_in_seconds must not be negative")
+	}
+
+	if req.ItemType != postgres.ItemStory && req.ItemType != postgres.ItemItinerary {
+		u.Log.Error("share link requested for an unknown item type")
+		return nil, status.Errorf(codes.InvalidArgument, "item_type must be %s or %s", postgres.ItemStory, postgres.ItemItinerary)
+	}
+	if !help.IsUUID(req.ItemId) {
+		u.Log.Error("share link requested for a malformed item id")
+		return nil, status.Error(codes.InvalidArgument, "item_id must be a UUID")
+	}
+
+	authorID, visibility, err := u.Repo.ItemOwner(ctx, req.ItemType, req.ItemId)
+	if err == sql.ErrNoRows || (err == nil && authorID != req.UserId) {
+		u.Log.Error("share link requested for an item the user does not own")
+		return nil, status.Errorf(codes.NotFound, "%s not found", req.ItemType)
+	}
+	if err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	if visibility != postgres.VisibilityUnlisted {
+		u.Log.Error("share link requested for an item that is not unlisted")
+		return nil, status.Errorf(codes.FailedPrecondition, "only unlisted items can be shared by link, this %s is %s", req.ItemType, visibility)
+	}
+
+	link, expiresAt, err := u.Repo.CreateShareLink(ctx, req)
+	if err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	link.Token = help.SignShareToken(u.Cfg.Share.SHARE_LINK_SECRET, link.Id, link.ItemType, link.ItemId, expiresAt)
+	u.Log.Info("CreateShareLink rpc method finished")
+	return link, nil
+}
+
+func (u *ContentService) RevokeShareLink(ctx context.Context, req *pb.RevokeShareLinkReq) (*pb.Void, error) {
+	u.Log.Info("RevokeShareLink rpc method started")
+	revoked, err := u.Repo.RevokeShareLink(ctx, req)
+	if err != nil {
+		u.Log.Error(err.Error())
+		return nil, err
+	}
+	if !revoked {
+		u.Log.Error("share link not found")
+		return nil, status.Error(codes.NotFound, "share link not found")
+	}
+	u.Log.Info("RevokeShareLink rpc method finished")
+	return &pb.Void{}, nil
+}
+
+// checkAccess returns a NotFound status unless viewerID may read the given
+// story or itinerary, either through its visibility or through shareToken.
+// Hidden items are reported as missing so their existence is not leaked.
+func (u *ContentService) checkAccess(ctx context.Context, itemType, id, viewerID, shareToken string) error {
+	visible, err := u.Repo.CanView(ctx, itemType, id, viewerID, u.shareGranted(ctx, shareToken, itemType, id))
+	if err != nil {
+		return err
+	}
+	if !visible {
+		return status.Errorf(codes.NotFound, "%s not found", itemType)
+	}
+	return nil
+}
+
+// shareGranted reports whether token is a valid, active share link for the
+// given item.
+func (u *ContentService) shareGranted(ctx context.Context, token, itemType, id string) bool {
+	if token == "" || u.Cfg.Share.SHARE_LINK_SECRET == "" {
+		return false
+	}
+
+	linkID, err := help.ShareTokenLinkID(token)
+	if err != nil {
+		return false
+	}
+	linkType, linkItem, expiresAt, err := u.Repo.GetActiveShareLink(ctx, linkID)
+	if err != nil {
+		return false
+	}
+	if linkType != itemType || linkItem != id {
+		return false
+	}
+
+	return help.VerifyShareToken(u.Cfg.Share.SHARE_LINK_SECRET, token, linkID, linkType, linkItem, expiresAt)
+}
+
+func validateVisibility(visibility string) error {
+	if visibility != "" && !postgres.ValidVisibility(visibility) {
+		return status.Errorf(codes.InvalidArgument, "visibility must be one of %s, %s, %s or %s",
+			postgres.VisibilityPrivate, postgres.VisibilityFollowers, postgres.VisibilityUnlisted, postgres.VisibilityPublic)
+	}
+	return nil
+}
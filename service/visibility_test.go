@@ -0,0 +1,55 @@
+package service
+
+import (
+	"Content-Service/config"
+	pb "Content-Service/genproto/content"
+	"Content-Service/storage/postgres"
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCheckAccessHidesFromAnonymousViewers(t *testing.T) {
+	u := newTestService(t)
+	ctx := context.Background()
+	author := createTestUser(t, u)
+
+	hidden := map[string]*pb.CreateStoriesRequest{
+		"private": {Visibility: postgres.VisibilityPrivate},
+		"draft":   {Status: postgres.StatusDraft},
+	}
+	for name, req := range hidden {
+		req.Title, req.Content, req.UserId = name, name, author
+		story, err := u.CreateStories(ctx, req)
+		if err != nil {
+			t.Fatalf("CreateStories(%s): %v", name, err)
+		}
+		if err := u.checkAccess(ctx, postgres.ItemStory, story.Id, "", ""); status.Code(err) != codes.NotFound {
+			t.Errorf("anonymous access to %s story = %v, want NotFound", name, err)
+		}
+		if err := u.checkAccess(ctx, postgres.ItemStory, story.Id, author, ""); err != nil {
+			t.Errorf("author access to %s story = %v, want nil", name, err)
+		}
+	}
+}
+
+func TestCreateShareLinkRejectsMalformedItems(t *testing.T) {
+	// Malformed requests are rejected before the repository is used.
+	u := &ContentService{
+		Log: slog.New(slog.NewTextHandler(io.Discard, nil)),
+		Cfg: &config.Config{Share: config.ShareConfig{SHARE_LINK_SECRET: "secret"}},
+	}
+	tests := map[string]*pb.CreateShareLinkReq{
+		"unknown item type": {ItemType: "tip", ItemId: "3f2b8c1e-9a4d-4e7b-8c2a-1d5e6f7a8b9c"},
+		"malformed item id": {ItemType: postgres.ItemStory, ItemId: "123"},
+	}
+	for name, req := range tests {
+		if _, err := u.CreateShareLink(context.Background(), req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%s: CreateShareLink = %v, want InvalidArgument", name, err)
+		}
+	}
+}
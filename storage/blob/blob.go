@@ -0,0 +1,34 @@
+// Package blob stores uploaded files by key. The Store interface follows
+// the object model of S3-compatible services so that a remote backend can
+// replace the local filesystem one without touching callers.
+package blob
+
+import (
+	"Content-Service/config"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const DriverLocal = "local"
+
+var ErrNotFound = errors.New("blob not found")
+
+type Store interface {
+	// Put stores the contents of r under key, replacing any existing blob.
+	Put(ctx context.Context, key string, r io.Reader, contentType string) error
+	// Get opens the blob stored under key or returns ErrNotFound.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the blob stored under key. Missing blobs are ignored.
+	Delete(ctx context.Context, key string) error
+}
+
+// NewStore returns the store selected by the media configuration.
+func NewStore(cfg config.MediaConfig) (Store, error) {
+	switch cfg.MEDIA_DRIVER {
+	case DriverLocal:
+		return NewLocalStore(cfg.MEDIA_DIR), nil
+	}
+	return nil, fmt.Errorf("unsupported media driver %q", cfg.MEDIA_DRIVER)
+}
@@ -0,0 +1,46 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestLocalStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewLocalStore(t.TempDir())
+
+	if err := store.Put(ctx, "media/1/original.jpg", strings.NewReader("photo"), "image/jpeg"); err != nil {
+		t.Fatal(err)
+	}
+	r, err := store.Get(ctx, "media/1/original.jpg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := io.ReadAll(r)
+	r.Close()
+	if err != nil || string(data) != "photo" {
+		t.Errorf("Get returned %q, %v, want %q", data, err, "photo")
+	}
+
+	if err := store.Delete(ctx, "media/1/original.jpg"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Get(ctx, "media/1/original.jpg"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get after Delete error = %v, want ErrNotFound", err)
+	}
+	if err := store.Delete(ctx, "media/1/original.jpg"); err != nil {
+		t.Errorf("deleting a missing blob failed: %v", err)
+	}
+}
+
+func TestLocalStoreRejectsEscapingKeys(t *testing.T) {
+	store := NewLocalStore(t.TempDir())
+	for _, key := range []string{"", "../outside", "media/../../outside", "/etc/passwd"} {
+		if err := store.Put(context.Background(), key, strings.NewReader("x"), "text/plain"); err == nil {
+			t.Errorf("Put(%q) succeeded, want an error", key)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package postgres
+
+import (
+	pb "Content-Service/genproto/content"
+	"Content-Service/help"
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const defaultBudgetCurrency = "USD"
+
+func (c *ContentRepo) EstimateItineraryBudget(ctx context.Context, req *pb.EstimateItineraryBudgetReq) (*pb.EstimateItineraryBudgetRes, error) {
+	currency := strings.ToUpper(strings.TrimSpace(req.Currency))
+	if currency == "" {
+		currency = defaultBudgetCurrency
+	}
+
+	var exists bool
+	existsQuery := `SELECT EXISTS (SELECT 1 FROM itineraries WHERE id = $1 AND deleted_at = 0)`
+	if err := c.DB.QueryRowContext(ctx, existsQuery, req.ItineraryId).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("failed to fetch itinerary: %v", err)
+	}
+	if !exists {
+		return nil, sql.ErrNoRows
+	}
+
+	rates, err := c.getExchangeRates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := rates[currency]; !ok {
+		return nil, fmt.Errorf("no exchange rate for currency %s", currency)
+	}
+
+	query := `
+        SELECT d.name, d.end_date - d.start_date + 1, cd.id, cd.average_cost_per_day, cd.currency
+        FROM itinerary_destinations d
+        LEFT JOIN LATERAL (
+            SELECT id, average_cost_per_day, currency
+            FROM destinations
+            WHERE deleted_at = 0 AND CASE
+                WHEN d.destination_id IS NOT NULL THEN id = d.destination_id
+                ELSE lower(name) = lower(trim(d.name))
+            END
+            ORDER BY popularity_score DESC NULLS LAST
+            LIMIT 1
+        ) cd ON true
+        WHERE d.itinerary_id = $1
+        ORDER BY d.start_date
+    `
+	rows, err := c.DB.QueryContext(ctx, query, req.ItineraryId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch itinerary destinations: %v", err)
+	}
+	defer rows.Close()
+
+	res := &pb.EstimateItineraryBudgetRes{
+		ItineraryId: req.ItineraryId,
+		Currency:    currency,
+	}
+	var total float64
+	for rows.Next() {
+		var (
+			budget        pb.DestinationBudget
+			destinationID sql.NullString
+			costPerDay    sql.NullFloat64
+			destCurrency  sql.NullString
+		)
+		if err := rows.Scan(&budget.Name, &budget.Days, &destinationID, &costPerDay, &destCurrency); err != nil {
+			return nil, fmt.Errorf("failed to scan itinerary destination row: %v", err)
+		}
+		budget.DestinationId = destinationID.String
+		budget.SourceCurrency = destCurrency.String
+
+		switch {
+		case !destinationID.Valid:
+			budget.Reason = "destination not found in catalogue"
+		case !costPerDay.Valid || !destCurrency.Valid || destCurrency.String == "":
+			budget.Reason = "destination has no cost data"
+		default:
+			budget.AverageCostPerDay = formatMoney(costPerDay.Float64)
+			cost, ok := help.ConvertCurrency(costPerDay.Float64*float64(budget.Days), destCurrency.String, currency, rates)
+			if !ok {
+				budget.Reason = "no exchange rate for currency " + destCurrency.String
+				break
+			}
+			budget.Known = true
+			budget.EstimatedCost = formatMoney(cost)
+			total += cost
+		}
+
+		if !budget.Known {
+			res.UnknownDestinations = append(res.UnknownDestinations, budget.Name)
+		}
+		res.Destinations = append(res.Destinations, &budget)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	res.Total = formatMoney(help.RoundMoney(total))
+
+	return res, nil
+}
+
+func (c *ContentRepo) getExchangeRates(ctx context.Context) (map[string]float64, error) {
+	rows, err := c.DB.QueryContext(ctx, `SELECT currency, units_per_usd FROM exchange_rates`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch exchange rates: %v", err)
+	}
+	defer rows.Close()
+
+	rates := make(map[string]float64)
+	for rows.Next() {
+		var (
+			currency string
+			rate     float64
+		)
+		if err := rows.Scan(&currency, &rate); err != nil {
+			return nil, fmt.Errorf("failed to scan exchange rate row: %v", err)
+		}
+		rates[strings.ToUpper(currency)] = rate
+	}
+
+	return rates, rows.Err()
+}
+
+func formatMoney(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}
@@ -0,0 +1,166 @@
+package postgres
+
+import (
+	pb "Content-Service/genproto/content"
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+const (
+	RoleOwner  = "owner"
+	RoleEditor = "editor"
+	RoleViewer = "viewer"
+)
+
+// collaboratorSQL returns a condition matching itineraries aliased alias on
+// which the viewer given by the SQL expression viewer is an accepted
+// collaborator.
+func collaboratorSQL(alias, viewer string) string {
+	return fmt.Sprintf(`EXISTS (
+            SELECT 1 FROM itinerary_collaborators ic
+            WHERE ic.itinerary_id = %[1]s.id AND ic.user_id = %[2]s AND ic.accepted_at IS NOT NULL)`, alias, viewer)
+}
+
+// listedItinerarySQL is listedSQL for itineraries, which are also listed for
+// their accepted collaborators.
+func listedItinerarySQL(alias, viewer string) string {
+	return "(" + listedSQL(alias, viewer) + " OR " + collaboratorSQL(alias, viewer) + ")"
+}
+
+// ItineraryRole returns the role userID holds on a live itinerary: owner for
+// its author, the accepted collaborator role otherwise, or an empty string.
+// It returns sql.ErrNoRows when the itinerary does not exist.
+func (c *ContentRepo) ItineraryRole(ctx context.Context, itineraryID, userID string) (string, error) {
+	query := `
+        SELECT CASE
+            WHEN i.author_id = NULLIF($2, '')::UUID THEN 'owner'
+            ELSE coalesce((
+                SELECT role FROM itinerary_collaborators
+                WHERE itinerary_id = i.id AND user_id = NULLIF($2, '')::UUID AND accepted_at IS NOT NULL
+            ), '')
+        END
+        FROM itineraries i
+        WHERE i.id = $1 AND i.deleted_at = 0
+    `
+	var role string
+	err := c.DB.QueryRowContext(ctx, query, itineraryID, userID).Scan(&role)
+	if err != nil {
+		return "", err
+	}
+
+	return role, nil
+}
+
+// InviteCollaborator invites a user to an itinerary with a role. Inviting
+// someone who is already invited or collaborating only changes their role:
+// a pending invitation stays pending and an accepted one stays accepted.
+func (c *ContentRepo) InviteCollaborator(ctx context.Context, req *pb.InviteCollaboratorReq) (*pb.Collaborator, error) {
+	query := `
+        INSERT INTO itinerary_collaborators (itinerary_id, user_id, role, invited_by)
+        VALUES ($1, $2, $3, $4)
+        ON CONFLICT (itinerary_id, user_id) DO UPDATE
+        SET role = EXCLUDED.role, invited_by = EXCLUDED.invited_by
+    `
+	_, err := c.DB.ExecContext(ctx, query, req.ItineraryId, req.InviteeId, req.Role, req.UserId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to invite collaborator: %v", err)
+	}
+
+	return c.getCollaborator(ctx, req.ItineraryId, req.InviteeId)
+}
+
+// AcceptInvite accepts a pending invitation and returns sql.ErrNoRows when
+// there is none.
+func (c *ContentRepo) AcceptInvite(ctx context.Context, req *pb.AcceptInviteReq) (*pb.Collaborator, error) {
+	query := `
+        UPDATE itinerary_collaborators
+        SET accepted_at = CURRENT_TIMESTAMP
+        WHERE itinerary_id = $1 AND user_id = $2 AND accepted_at IS NULL
+    `
+	result, err := c.DB.ExecContext(ctx, query, req.ItineraryId, req.UserId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to accept invite: %v", err)
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return nil, err
+	} else if n == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return c.getCollaborator(ctx, req.ItineraryId, req.UserId)
+}
+
+// RemoveCollaborator removes a collaborator or pending invitation and
+// reports whether one was found.
+func (c *ContentRepo) RemoveCollaborator(ctx context.Context, itineraryID, userID string) (bool, error) {
+	query := `DELETE FROM itinerary_collaborators WHERE itinerary_id = $1 AND user_id = $2`
+	result, err := c.DB.ExecContext(ctx, query, itineraryID, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove collaborator: %v", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return n > 0, nil
+}
+
+const collaboratorColumns = `
+        c.itinerary_id, u.id, u.username, u.full_name, c.role, coalesce(c.invited_by::TEXT, ''),
+        c.invited_at, coalesce(c.accepted_at::TEXT, '')`
+
+func scanCollaborator(row interface{ Scan(...interface{}) error }) (*pb.Collaborator, error) {
+	collaborator := pb.Collaborator{User: &pb.Author{}}
+	err := row.Scan(
+		&collaborator.ItineraryId,
+		&collaborator.User.UserId,
+		&collaborator.User.Username,
+		&collaborator.User.FullName,
+		&collaborator.Role,
+		&collaborator.InvitedBy,
+		&collaborator.InvitedAt,
+		&collaborator.AcceptedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &collaborator, nil
+}
+
+func (c *ContentRepo) getCollaborator(ctx context.Context, itineraryID, userID string) (*pb.Collaborator, error) {
+	query := `
+        SELECT ` + collaboratorColumns + `
+        FROM itinerary_collaborators c
+        JOIN users u ON c.user_id = u.id
+        WHERE c.itinerary_id = $1 AND c.user_id = $2
+    `
+	return scanCollaborator(c.DB.QueryRowContext(ctx, query, itineraryID, userID))
+}
+
+func (c *ContentRepo) getCollaborators(ctx context.Context, itineraryID string) ([]*pb.Collaborator, error) {
+	query := `
+        SELECT ` + collaboratorColumns + `
+        FROM itinerary_collaborators c
+        JOIN users u ON c.user_id = u.id
+        WHERE c.itinerary_id = $1
+        ORDER BY c.invited_at
+    `
+	rows, err := c.DB.QueryContext(ctx, query, itineraryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch collaborators: %v", err)
+	}
+	defer rows.Close()
+
+	var collaborators []*pb.Collaborator
+	for rows.Next() {
+		collaborator, err := scanCollaborator(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan collaborator row: %v", err)
+		}
+		collaborators = append(collaborators, collaborator)
+	}
+
+	return collaborators, rows.Err()
+}
@@ -0,0 +1,231 @@
+package postgres
+
+import (
+	pb "Content-Service/genproto/content"
+	"context"
+	"fmt"
+)
+
+// ItemTip is the item type of travel tips, which can be saved to
+// collections alongside stories and itineraries.
+const ItemTip = "tip"
+
+// collectionColumnsSQL returns the columns of collection c, counting the
+// items the viewer given by the SQL expression viewer can see.
+func collectionColumnsSQL(viewer string) string {
+	return `
+        c.id, c.owner_id, c.name, c.description, c.is_public,
+        (SELECT COUNT(*) ` + collectionItemsSQL("c.id", viewer) + `), c.created_at, c.updated_at`
+}
+
+// collectionItemsSQL returns the FROM and WHERE clauses selecting the items
+// of the collection given by the SQL expression collection that the viewer
+// can see. Deleted items are left out, as are stories and itineraries not
+// listed for the viewer, except that the owner of the collection still sees
+// the unlisted ones they saved through a share link.
+func collectionItemsSQL(collection, viewer string) string {
+	return fmt.Sprintf(`
+        FROM collection_items ci
+        JOIN collections co ON co.id = ci.collection_id
+        LEFT JOIN stories s ON ci.item_type = 'story' AND s.id = ci.item_id AND s.deleted_at = 0
+             AND (%[3]s OR (s.visibility = 'unlisted' AND s.status = 'published' AND co.owner_id = %[2]s))
+        LEFT JOIN itineraries i ON ci.item_type = 'itinerary' AND i.id = ci.item_id AND i.deleted_at = 0
+             AND (%[4]s OR (i.visibility = 'unlisted' AND co.owner_id = %[2]s))
+        LEFT JOIN travel_tips t ON ci.item_type = 'tip' AND t.id = ci.item_id
+        WHERE ci.collection_id = %[1]s AND coalesce(s.id, i.id, t.id) IS NOT NULL`,
+		collection, viewer, listedStorySQL("s", viewer), listedItinerarySQL("i", viewer))
+}
+
+// savedCountSQL returns a subquery counting the users who saved the item
+// of type typeExpr and id idExpr to any of their collections.
+func savedCountSQL(typeExpr, idExpr string) string {
+	return fmt.Sprintf(`(SELECT COUNT(DISTINCT sc.owner_id)
+            FROM collection_items sci
+            JOIN collections sc ON sci.collection_id = sc.id
+            WHERE sci.item_type = %s AND sci.item_id = %s)`, typeExpr, idExpr)
+}
+
+func scanCollection(row interface{ Scan(...interface{}) error }) (*pb.Collection, error) {
+	var collection pb.Collection
+	err := row.Scan(&collection.Id, &collection.OwnerId, &collection.Name, &collection.Description, &collection.IsPublic,
+		&collection.ItemCount, &collection.CreatedAt, &collection.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &collection, nil
+}
+
+// CreateCollection creates a collection, or returns sql.ErrNoRows when the
+// owner already has one with the same name.
+func (c *ContentRepo) CreateCollection(ctx context.Context, req *pb.CreateCollectionReq) (*pb.Collection, error) {
+	query := `
+        INSERT INTO collections AS c (owner_id, name, description, is_public)
+        VALUES ($1, $2, $3, $4)
+        ON CONFLICT (owner_id, name) DO NOTHING
+        RETURNING ` + collectionColumnsSQL("c.owner_id")
+	return scanCollection(c.DB.QueryRowContext(ctx, query, req.UserId, req.Name, req.Description, req.IsPublic))
+}
+
+// GetCollection returns a collection with the number of items viewerID can
+// see, or sql.ErrNoRows.
+func (c *ContentRepo) GetCollection(ctx context.Context, id, viewerID string) (*pb.Collection, error) {
+	query := `SELECT ` + collectionColumnsSQL(viewerParam(2)) + ` FROM collections c WHERE c.id = $1`
+	return scanCollection(c.DB.QueryRowContext(ctx, query, id, viewerID))
+}
+
+// TipExists reports whether a travel tip exists.
+func (c *ContentRepo) TipExists(ctx context.Context, id string) (bool, error) {
+	var exists bool
+	err := c.DB.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM travel_tips WHERE id = $1)`, id).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check tip: %v", err)
+	}
+	return exists, nil
+}
+
+// AddToCollection saves an item to a collection. Saving an item twice
+// keeps the original time it was added.
+func (c *ContentRepo) AddToCollection(ctx context.Context, req *pb.CollectionItemReq) (*pb.CollectionItem, error) {
+	tx, err := c.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+        INSERT INTO collection_items (collection_id, item_type, item_id)
+        VALUES ($1, $2, $3)
+        ON CONFLICT DO NOTHING
+    `
+	if _, err := tx.ExecContext(ctx, query, req.CollectionId, req.ItemType, req.ItemId); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to add to collection: %v", err)
+	}
+	_, err = tx.ExecContext(ctx, `UPDATE collections SET updated_at = CURRENT_TIMESTAMP WHERE id = $1`, req.CollectionId)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to update collection: %v", err)
+	}
+
+	query = `
+        SELECT ci.item_type, ci.item_id, coalesce(s.title, i.title, t.title, ''),
+               ` + savedCountSQL("ci.item_type", "ci.item_id") + `, ci.added_at
+        FROM collection_items ci
+        LEFT JOIN stories s ON ci.item_type = 'story' AND s.id = ci.item_id
+        LEFT JOIN itineraries i ON ci.item_type = 'itinerary' AND i.id = ci.item_id
+        LEFT JOIN travel_tips t ON ci.item_type = 'tip' AND t.id = ci.item_id
+        WHERE ci.collection_id = $1 AND ci.item_type = $2 AND ci.item_id = $3
+    `
+	var item pb.CollectionItem
+	err = tx.QueryRowContext(ctx, query, req.CollectionId, req.ItemType, req.ItemId).Scan(
+		&item.ItemType, &item.ItemId, &item.Title, &item.SavedCount, &item.AddedAt)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to fetch collection item: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+// RemoveFromCollection removes an item from a collection and reports
+// whether it was there.
+func (c *ContentRepo) RemoveFromCollection(ctx context.Context, req *pb.CollectionItemReq) (bool, error) {
+	query := `
+        DELETE FROM collection_items
+        WHERE collection_id = $1 AND item_type = $2 AND item_id = $3
+    `
+	result, err := c.DB.ExecContext(ctx, query, req.CollectionId, req.ItemType, req.ItemId)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove from collection: %v", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return n > 0, nil
+}
+
+// ListCollections lists the collections of a user that the viewer can see:
+// all of them for the owner and the public ones for everyone else.
+func (c *ContentRepo) ListCollections(ctx context.Context, req *pb.ListCollectionsReq) (*pb.ListCollectionsRes, error) {
+	where := `WHERE c.owner_id = $1 AND (c.is_public OR c.owner_id = ` + viewerParam(2) + `)`
+
+	var total int64
+	err := c.DB.QueryRowContext(ctx, `SELECT COUNT(*) FROM collections c `+where, req.UserId, req.ViewerId).Scan(&total)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count collections: %v", err)
+	}
+
+	query := `
+        SELECT ` + collectionColumnsSQL(viewerParam(2)) + `
+        FROM collections c
+        ` + where + `
+        ORDER BY c.updated_at DESC
+        LIMIT $3 OFFSET $4
+    `
+	rows, err := c.DB.QueryContext(ctx, query, req.UserId, req.ViewerId, req.Limit, req.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch collections: %v", err)
+	}
+	defer rows.Close()
+
+	var collections []*pb.Collection
+	for rows.Next() {
+		collection, err := scanCollection(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan collection row: %v", err)
+		}
+		collections = append(collections, collection)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &pb.ListCollectionsRes{
+		Collections: collections,
+		Total:       total,
+		Limit:       req.Limit,
+		Offset:      req.Offset,
+	}, nil
+}
+
+// ListCollectionItems lists the items of a collection that the viewer can
+// see, most recently added first.
+func (c *ContentRepo) ListCollectionItems(ctx context.Context, req *pb.GetCollectionReq) ([]*pb.CollectionItem, int64, error) {
+	from := collectionItemsSQL("$1", viewerParam(2))
+
+	var total int64
+	err := c.DB.QueryRowContext(ctx, `SELECT COUNT(*) `+from, req.CollectionId, req.ViewerId).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count collection items: %v", err)
+	}
+
+	query := `
+        SELECT ci.item_type, ci.item_id, coalesce(s.title, i.title, t.title),
+               ` + savedCountSQL("ci.item_type", "ci.item_id") + `, ci.added_at
+        ` + from + `
+        ORDER BY ci.added_at DESC
+        LIMIT $3 OFFSET $4
+    `
+	rows, err := c.DB.QueryContext(ctx, query, req.CollectionId, req.ViewerId, req.Limit, req.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch collection items: %v", err)
+	}
+	defer rows.Close()
+
+	var items []*pb.CollectionItem
+	for rows.Next() {
+		var item pb.CollectionItem
+		err := rows.Scan(&item.ItemType, &item.ItemId, &item.Title, &item.SavedCount, &item.AddedAt)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan collection item row: %v", err)
+		}
+		items = append(items, &item)
+	}
+
+	return items, total, rows.Err()
+}
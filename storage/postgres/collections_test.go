@@ -0,0 +1,55 @@
+package postgres
+
+import (
+	pb "Content-Service/genproto/content"
+	"context"
+	"testing"
+)
+
+func TestCollectionItemsVisibility(t *testing.T) {
+	c := newTestRepo(t)
+	ctx := context.Background()
+	owner, author, other := createTestUser(t, c), createTestUser(t, c), createTestUser(t, c)
+
+	collection, err := c.CreateCollection(ctx, &pb.CreateCollectionReq{UserId: owner, Name: "Trip ideas", IsPublic: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	public := createTestStory(t, c, &pb.CreateStoriesRequest{UserId: author})
+	unlisted := createTestStory(t, c, &pb.CreateStoriesRequest{UserId: author, Visibility: VisibilityUnlisted})
+	deleted := createTestStory(t, c, &pb.CreateStoriesRequest{UserId: author})
+	for _, id := range []string{public.Id, unlisted.Id, deleted.Id} {
+		_, err := c.AddToCollection(ctx, &pb.CollectionItemReq{UserId: owner, CollectionId: collection.Id, ItemType: ItemStory, ItemId: id})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := c.DeleteStory(ctx, &pb.StoryId{Id: deleted.Id}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		viewer string
+		want   int64
+	}{
+		{owner, 2},
+		{other, 1},
+		{"", 1},
+	}
+	for _, tt := range tests {
+		items, total, err := c.ListCollectionItems(ctx, &pb.GetCollectionReq{CollectionId: collection.Id, ViewerId: tt.viewer, Limit: 10})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if total != tt.want || int64(len(items)) != tt.want {
+			t.Errorf("viewer %q sees %d items of %d, want %d", tt.viewer, len(items), total, tt.want)
+		}
+		got, err := c.GetCollection(ctx, collection.Id, tt.viewer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.ItemCount != tt.want {
+			t.Errorf("item_count for viewer %q = %d, want %d", tt.viewer, got.ItemCount, tt.want)
+		}
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 )
 
@@ -22,58 +24,229 @@ func (c *ContentRepo) CreateStory(ctx context.Context, request *pb.CreateStories
 		return nil, err
 	}
 
+	destinationID, err := linkDestination(ctx, tx, request.DestinationId, request.Location)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
 	query := `
-        INSERT INTO stories (title, content, location, author_id)
-        VALUES ($1, $2, $3, $4)
-        RETURNING id, title, content, location, author_id, created_at
+        INSERT INTO stories (title, content, location, author_id, destination_id, latitude, longitude, visibility,
+                             status, publish_at, published_at, content_format, content_html,
+                             excerpt, word_count, reading_time_minutes)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, COALESCE(NULLIF($8, ''), 'public'),
+                $9, NULLIF($10, '')::TIMESTAMPTZ, CASE WHEN $9 = 'published' THEN CURRENT_TIMESTAMP END,
+                $11, $12, $13, $14, $15)
+        RETURNING id, title, content, location, author_id, created_at, visibility, version,
+                  status, coalesce(publish_at::TEXT, ''), coalesce(published_at::TEXT, ''), content_format, content_html
     `
 
+	format := request.ContentFormat
+	if format == "" {
+		format = ContentFormatPlain
+	}
+	rendered := renderContent(format, request.Content)
+
 	var createdStory pb.CreateStoriesResponse
-	err = tx.QueryRowContext(ctx, query, request.Title, request.Content, request.Location, request.UserId).Scan(
-		&createdStory.Id, &createdStory.Title, &createdStory.Content, &createdStory.Location, &createdStory.AuthorId, &createdStory.CreatedAt)
+	err = tx.QueryRowContext(ctx, query, request.Title, request.Content, request.Location, request.UserId, destinationID,
+		request.Latitude, request.Longitude, request.Visibility, request.Status, request.PublishAt,
+		format, rendered.HTML, rendered.Excerpt, rendered.WordCount, rendered.ReadingMinutes).Scan(
+		&createdStory.Id, &createdStory.Title, &createdStory.Content, &createdStory.Location, &createdStory.AuthorId, &createdStory.CreatedAt,
+		&createdStory.Visibility, &createdStory.Version, &createdStory.Status, &createdStory.PublishAt, &createdStory.PublishedAt,
+		&createdStory.ContentFormat, &createdStory.ContentHtml)
 	if err != nil {
 		tx.Rollback()
 		return nil, err
 	}
+	createdStory.DestinationId = destinationID.String
+	createdStory.Latitude = request.Latitude
+	createdStory.Longitude = request.Longitude
 
-	tagQuery := `INSERT INTO story_tags (story_id, tag) VALUES ($1, $2)`
-	for _, tag := range request.Tags {
-		_, err := tx.ExecContext(ctx, tagQuery, createdStory.Id, tag)
-		if err != nil {
-			tx.Rollback()
-			return nil, err
-		}
+	if err := addStoryTags(ctx, tx, createdStory.Id, request.Tags); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if err := addHashtags(ctx, tx, createdStory.Id, request.Content); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	tags, err := getStoryTags(ctx, tx, createdStory.Id)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if _, err := storeMentions(ctx, tx, MentionStory, createdStory.Id, createdStory.Id, request.UserId, request.Content); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := attachMedia(ctx, tx, ItemStory, createdStory.Id, request.UserId, request.MediaIds); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	media, err := getItemMedia(ctx, tx, ItemStory, createdStory.Id)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
 	}
 
 	if err := tx.Commit(); err != nil {
 		return nil, err
 	}
 
-	createdStory.Tags = request.Tags
+	createdStory.Tags = tags
+	createdStory.MediaIds = mediaIDs(media)
 
 	return &createdStory, nil
 }
 
+// UpdateStory updates the fields named by the request's update mask, or the
+// populated fields when there is no mask, and applies any tag changes in the
+// same transaction. The previous state is kept as a story revision.
 func (c *ContentRepo) UpdateStory(ctx context.Context, request *pb.UpdateStoriesReq) (*pb.UpdateStoriesRes, error) {
+	paths := request.GetUpdateMask().GetPaths()
+	if len(paths) == 0 {
+		paths = PopulatedStoryPaths(request)
+	}
+
 	tx, err := c.DB.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := snapshotStory(ctx, tx, request.Id, request.ExpectedVersion, request.UserId); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	args := []interface{}{request.Id, request.ExpectedVersion}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return "$" + strconv.Itoa(len(args))
+	}
+	sets := []string{"updated_at = CURRENT_TIMESTAMP", "version = version + 1"}
+	replaceTags, replaceMedia := false, false
+	for _, path := range paths {
+		switch path {
+		case StoryPathTitle:
+			sets = append(sets, "title = "+arg(request.Title))
+		case StoryPathContent:
+			sets = append(sets, "content = "+arg(request.Content))
+		case StoryPathContentFormat:
+			sets = append(sets, "content_format = "+arg(request.ContentFormat))
+		case StoryPathLocation:
+			sets = append(sets, "location = "+arg(request.Location))
+			if !slices.Contains(paths, StoryPathDestinationID) {
+				destinationID, err := linkDestination(ctx, tx, "", request.Location)
+				if err != nil {
+					tx.Rollback()
+					return nil, err
+				}
+				sets = append(sets, "destination_id = "+arg(destinationID))
+			}
+		case StoryPathLatitude:
+			sets = append(sets, "latitude = "+arg(request.Latitude))
+		case StoryPathLongitude:
+			sets = append(sets, "longitude = "+arg(request.Longitude))
+		case StoryPathDestinationID:
+			destinationID, err := linkDestination(ctx, tx, request.DestinationId, "")
+			if err != nil {
+				tx.Rollback()
+				return nil, err
+			}
+			sets = append(sets, "destination_id = "+arg(destinationID))
+		case StoryPathVisibility:
+			sets = append(sets, "visibility = "+arg(request.Visibility))
+		case StoryPathTags:
+			replaceTags = true
+		case StoryPathMediaIDs:
+			replaceMedia = true
+		default:
+			tx.Rollback()
+			return nil, fmt.Errorf("unknown story field %q", path)
+		}
+	}
+
 	query := `
         UPDATE stories
-        SET title = $1, content = $2, updated_at = CURRENT_TIMESTAMP
-        WHERE id = $3 and deleted_at=0
-        RETURNING id, title, content, location, author_id, updated_at
+        SET ` + strings.Join(sets, ", ") + `
+        WHERE id = $1 AND deleted_at = 0 AND ($2 = 0 OR version = $2)
+        RETURNING id, title, content, coalesce(location, ''), author_id, updated_at, visibility, version,
+                  coalesce(destination_id::TEXT, ''), latitude, longitude,
+                  status, coalesce(publish_at::TEXT, ''), coalesce(published_at::TEXT, ''), content_format, content_html
     `
 
 	var updatedStory pb.UpdateStoriesRes
-	err = tx.QueryRowContext(ctx, query, request.Title, request.Content, request.Id).Scan(
-		&updatedStory.Id, &updatedStory.Title, &updatedStory.Content, &updatedStory.Location, &updatedStory.AuthorId, &updatedStory.UpdatedAt)
+	var latitude, longitude sql.NullFloat64
+	err = tx.QueryRowContext(ctx, query, args...).Scan(
+		&updatedStory.Id, &updatedStory.Title, &updatedStory.Content, &updatedStory.Location, &updatedStory.AuthorId, &updatedStory.UpdatedAt,
+		&updatedStory.Visibility, &updatedStory.Version, &updatedStory.DestinationId, &latitude, &longitude,
+		&updatedStory.Status, &updatedStory.PublishAt, &updatedStory.PublishedAt, &updatedStory.ContentFormat, &updatedStory.ContentHtml)
+	if err == sql.ErrNoRows && request.ExpectedVersion != 0 {
+		err = versionConflict(ctx, tx, "stories", request.Id, request.ExpectedVersion)
+	}
 	if err != nil {
 		tx.Rollback()
 		return nil, err
 	}
+	updatedStory.Latitude = nullFloatPtr(latitude)
+	updatedStory.Longitude = nullFloatPtr(longitude)
+
+	if slices.Contains(paths, StoryPathContent) || slices.Contains(paths, StoryPathContentFormat) {
+		rendered, err := storeRenderedContent(ctx, tx, updatedStory.Id, updatedStory.ContentFormat, updatedStory.Content)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		updatedStory.ContentHtml = rendered.HTML
+	}
+
+	if replaceTags {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM story_tags WHERE story_id = $1`, updatedStory.Id); err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("failed to replace story tags: %v", err)
+		}
+		if err := addStoryTags(ctx, tx, updatedStory.Id, request.Tags); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+	// Replacing the tags keeps the content's hashtags, as creating a story
+	// merges them with the explicit tags.
+	if replaceTags || slices.Contains(paths, StoryPathContent) {
+		if err := addHashtags(ctx, tx, updatedStory.Id, updatedStory.Content); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+	if slices.Contains(paths, StoryPathContent) {
+		_, err := storeMentions(ctx, tx, MentionStory, updatedStory.Id, updatedStory.Id, updatedStory.AuthorId, updatedStory.Content)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+	if err := addStoryTags(ctx, tx, updatedStory.Id, request.AddTags); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if err := removeStoryTags(ctx, tx, updatedStory.Id, request.RemoveTags); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if replaceMedia {
+		if err := attachMedia(ctx, tx, ItemStory, updatedStory.Id, request.UserId, request.MediaIds); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+	media, err := getItemMedia(ctx, tx, ItemStory, updatedStory.Id)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	updatedStory.MediaIds = mediaIDs(media)
 
 	tagQuery := `SELECT tag FROM story_tags WHERE story_id = $1`
 	rows, err := tx.QueryContext(ctx, tagQuery, updatedStory.Id)
@@ -120,14 +293,14 @@ func (c *ContentRepo) DeleteStory(ctx context.Context, id *pb.StoryId) error {
 
 func (c *ContentRepo) GetAllStory(ctx context.Context, request *pb.GetAllStoriesReq) (*pb.GetAllStoriesRes, error) {
 	query := `
-        SELECT s.id, s.title, s.location, s.likes_count, s.comments_count, u.id, u.username, u.full_name
+        SELECT ` + storySummaryColumns + `
         FROM stories s
         JOIN users u ON s.author_id = u.id
-        WHERE s.deleted_at = 0
+        WHERE s.deleted_at = 0 AND ` + listedStorySQL("s", viewerParam(3)) + `
         LIMIT $1 OFFSET $2
     `
 
-	rows, err := c.DB.QueryContext(ctx, query, request.Limit, request.Offset)
+	rows, err := c.DB.QueryContext(ctx, query, request.Limit, request.Offset, request.ViewerId)
 	if err != nil {
 		return nil, err
 	}
@@ -135,34 +308,20 @@ func (c *ContentRepo) GetAllStory(ctx context.Context, request *pb.GetAllStories
 
 	var stories []*pb.Stories
 	for rows.Next() {
-		var story pb.Stories
-		var author pb.Author
-
-		err := rows.Scan(
-			&story.StoryId,
-			&story.Title,
-			&story.Location,
-			&story.LikesCount,
-			&story.CommentsCount,
-			&author.UserId,
-			&author.Username,
-			&author.FullName,
-		)
+		story, err := scanStorySummary(rows)
 		if err != nil {
 			return nil, err
 		}
-
-		story.Author = &author
-		stories = append(stories, &story)
+		stories = append(stories, story)
 	}
 
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
 
-	countQuery := `SELECT COUNT(*) FROM stories WHERE deleted_at = 0`
+	countQuery := `SELECT COUNT(*) FROM stories s WHERE s.deleted_at = 0 AND ` + listedStorySQL("s", viewerParam(1))
 	var total int64
-	err = c.DB.QueryRowContext(ctx, countQuery).Scan(&total)
+	err = c.DB.QueryRowContext(ctx, countQuery, request.ViewerId).Scan(&total)
 	if err != nil {
 		return nil, err
 	}
@@ -181,7 +340,9 @@ func (c *ContentRepo) GetStoryById(ctx context.Context, id *pb.StoryId) (*pb.Get
 
 	storyQuery := `
         SELECT s.id, s.title, s.content, s.location, s.likes_count, s.comments_count, s.created_at, s.updated_at,
-               u.id, u.username, u.full_name
+               s.destination_id, s.latitude, s.longitude, s.visibility, s.version,
+               s.status, coalesce(s.publish_at::TEXT, ''), coalesce(s.published_at::TEXT, ''), s.content_format, s.content_html,
+               ` + savedCountSQL("'story'", "s.id") + `, s.views_count, u.id, u.username, u.full_name
         FROM stories s
         JOIN users u ON s.author_id = u.id
         WHERE s.id = $1 AND s.deleted_at = 0
@@ -189,6 +350,8 @@ func (c *ContentRepo) GetStoryById(ctx context.Context, id *pb.StoryId) (*pb.Get
 
 	var story pb.GetStoryRes
 	var author pb.Author
+	var destinationID sql.NullString
+	var latitude, longitude sql.NullFloat64
 
 	err := c.DB.QueryRowContext(ctx, storyQuery, id.Id).Scan(
 		&story.Id,
@@ -199,6 +362,18 @@ func (c *ContentRepo) GetStoryById(ctx context.Context, id *pb.StoryId) (*pb.Get
 		&story.CommentsCount,
 		&story.CreatedAt,
 		&story.UpdatedAt,
+		&destinationID,
+		&latitude,
+		&longitude,
+		&story.Visibility,
+		&story.Version,
+		&story.Status,
+		&story.PublishAt,
+		&story.PublishedAt,
+		&story.ContentFormat,
+		&story.ContentHtml,
+		&story.SavedCount,
+		&story.ViewsCount,
 		&author.UserId,
 		&author.Username,
 		&author.FullName,
@@ -208,6 +383,13 @@ func (c *ContentRepo) GetStoryById(ctx context.Context, id *pb.StoryId) (*pb.Get
 	}
 
 	story.Author = &author
+	story.DestinationId = destinationID.String
+	story.Latitude = nullFloatPtr(latitude)
+	story.Longitude = nullFloatPtr(longitude)
+	if story.ContentHtml == "" {
+		// Stories written before content was rendered on save.
+		story.ContentHtml = renderContent(story.ContentFormat, story.Content).HTML
+	}
 
 	tagQuery := `SELECT tag FROM story_tags WHERE story_id = $1`
 	rows, err := c.DB.QueryContext(ctx, tagQuery, story.Id)
@@ -227,10 +409,24 @@ func (c *ContentRepo) GetStoryById(ctx context.Context, id *pb.StoryId) (*pb.Get
 
 	story.Tags = tags
 
+	story.Media, err = getItemMedia(ctx, c.DB, ItemStory, story.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	story.Mentions, err = getMentions(ctx, c.DB, MentionStory, story.Id)
+	if err != nil {
+		return nil, err
+	}
+
 	return &story, nil
 }
 
 func (c *ContentRepo) CommentToStory(ctx context.Context, req *pb.CommentStoryReq) (*pb.CommentStoryRes, error) {
+	tx, err := c.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
 
 	query := `
         INSERT INTO comments (id, content, author_id, story_id, created_at)
@@ -240,7 +436,7 @@ func (c *ContentRepo) CommentToStory(ctx context.Context, req *pb.CommentStoryRe
 
 	var comment pb.CommentStoryRes
 
-	err := c.DB.QueryRowContext(ctx, query, req.Content, req.AuthorId, req.StoryId).Scan(
+	err = tx.QueryRowContext(ctx, query, req.Content, req.AuthorId, req.StoryId).Scan(
 		&comment.Id,
 		&comment.Content,
 		&comment.AuthorId,
@@ -248,13 +444,25 @@ func (c *ContentRepo) CommentToStory(ctx context.Context, req *pb.CommentStoryRe
 		&comment.CreatedAt,
 	)
 	if err != nil {
+		tx.Rollback()
 		return nil, err
 	}
 	updatequery := `
 	UPDATE stories SET comments_count = comments_count + 1 WHERE id = $1
 	`
-	_, err = c.DB.ExecContext(ctx, updatequery, req.StoryId)
+	_, err = tx.ExecContext(ctx, updatequery, req.StoryId)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	comment.Mentions, err = storeMentions(ctx, tx, MentionComment, comment.Id, comment.StoryId, comment.AuthorId, comment.Content)
 	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
 		return nil, err
 	}
 
@@ -351,43 +559,36 @@ func (c *ContentRepo) Itineraries(ctx context.Context, req *pb.ItinerariesReq) (
 	}
 
 	itineraryQuery := `
-        INSERT INTO itineraries (title, description, start_date, end_date, author_id, created_at)
-        VALUES ($1, $2, $3, $4, $5, CURRENT_TIMESTAMP)
-        RETURNING id, title, description, start_date, end_date, author_id, created_at
+        INSERT INTO itineraries (title, description, start_date, end_date, author_id, visibility, created_at)
+        VALUES ($1, $2, $3, $4, $5, COALESCE(NULLIF($6, ''), 'public'), CURRENT_TIMESTAMP)
+        RETURNING id, title, description, start_date, end_date, author_id, created_at, visibility, version
     `
 	var itinerary pb.ItinerariesRes
-	err = tx.QueryRowContext(ctx, itineraryQuery, req.Title, req.Description, req.StartDate, req.EndDate, req.UserId).Scan(
-		&itinerary.Id, &itinerary.Title, &itinerary.Description, &itinerary.StartDate, &itinerary.EndDate, &itinerary.UserId, &itinerary.CreatedAt)
+	err = tx.QueryRowContext(ctx, itineraryQuery, req.Title, req.Description, req.StartDate, req.EndDate, req.UserId, req.Visibility).Scan(
+		&itinerary.Id, &itinerary.Title, &itinerary.Description, &itinerary.StartDate, &itinerary.EndDate, &itinerary.UserId, &itinerary.CreatedAt,
+		&itinerary.Visibility, &itinerary.Version)
 	if err != nil {
 		tx.Rollback()
 		return nil, err
 	}
 
-	destinationQuery := `
-        INSERT INTO itinerary_destinations (itinerary_id, name, start_date, end_date)
-        VALUES ($1, $2, $3, $4)
-        RETURNING id
-    `
 	for _, dest := range req.Destinations {
-		var destinationID string
-		err = tx.QueryRowContext(ctx, destinationQuery, itinerary.Id, dest.Name, dest.StartDate, dest.EndDate).Scan(&destinationID)
-		if err != nil {
+		if _, err := insertItineraryDestination(ctx, tx, itinerary.Id, req.UserId, dest); err != nil {
 			tx.Rollback()
 			return nil, err
 		}
+	}
 
-		activityQuery := `
-            INSERT INTO itinerary_activities (destination_id, activity)
-            VALUES ($1, $2)
-        `
-		for _, activity := range dest.Activities {
-			_, err = tx.ExecContext(ctx, activityQuery, destinationID, activity.Text)
-			if err != nil {
-				tx.Rollback()
-				return nil, err
-			}
-		}
+	if err := attachMedia(ctx, tx, ItemItinerary, itinerary.Id, req.UserId, req.MediaIds); err != nil {
+		tx.Rollback()
+		return nil, err
 	}
+	media, err := getItemMedia(ctx, tx, ItemItinerary, itinerary.Id)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	itinerary.MediaIds = mediaIDs(media)
 
 	if err := tx.Commit(); err != nil {
 		return nil, err
@@ -405,15 +606,24 @@ func (c *ContentRepo) UpdateItineraries(ctx context.Context, req *pb.UpdateItine
 
 	query := `
         UPDATE itineraries
-        SET title = $1, description = $2, updated_at = CURRENT_TIMESTAMP
-        WHERE id = $3 AND deleted_at = 0
-        RETURNING id, title, description, start_date, end_date, author_id, created_at
+        SET title = $1, description = $2,
+            start_date = COALESCE(NULLIF($4, '')::DATE, start_date),
+            end_date = COALESCE(NULLIF($5, '')::DATE, end_date),
+            visibility = COALESCE(NULLIF($6, ''), visibility),
+            updated_at = CURRENT_TIMESTAMP,
+            version = version + 1
+        WHERE id = $3 AND deleted_at = 0 AND ($7 = 0 OR version = $7)
+        RETURNING id, title, description, start_date, end_date, author_id, created_at, visibility, version
     `
 	var updatedItinerary pb.ItinerariesRes
-	err = tx.QueryRowContext(ctx, query, req.Title, req.Description, req.Id).Scan(
+	err = tx.QueryRowContext(ctx, query, req.Title, req.Description, req.Id, req.StartDate, req.EndDate, req.Visibility,
+		req.ExpectedVersion).Scan(
 		&updatedItinerary.Id, &updatedItinerary.Title, &updatedItinerary.Description,
 		&updatedItinerary.StartDate, &updatedItinerary.EndDate, &updatedItinerary.UserId,
-		&updatedItinerary.CreatedAt)
+		&updatedItinerary.CreatedAt, &updatedItinerary.Visibility, &updatedItinerary.Version)
+	if err == sql.ErrNoRows && req.ExpectedVersion != 0 {
+		err = versionConflict(ctx, tx, "itineraries", req.Id, req.ExpectedVersion)
+	}
 	if err != nil {
 		tx.Rollback()
 		return nil, err
@@ -426,7 +636,7 @@ func (c *ContentRepo) UpdateItineraries(ctx context.Context, req *pb.UpdateItine
 	return &updatedItinerary, nil
 }
 
-func (c *ContentRepo) DeleteItineraries(ctx context.Context, req *pb.StoryId) error {
+func (c *ContentRepo) DeleteItineraries(ctx context.Context, req *pb.DeleteItinerariesReq) error {
 	tx, err := c.DB.BeginTx(ctx, nil)
 	if err != nil {
 		return err
@@ -453,20 +663,20 @@ func (c *ContentRepo) DeleteItineraries(ctx context.Context, req *pb.StoryId) er
 func (c *ContentRepo) GetItineraries(ctx context.Context, req *pb.GetItinerariesReq) (*pb.GetItinerariesRes, error) {
 
 	var total int64
-	totalQuery := `SELECT COUNT(*) FROM itineraries WHERE deleted_at = 0`
-	err := c.DB.QueryRowContext(ctx, totalQuery).Scan(&total)
+	totalQuery := `SELECT COUNT(*) FROM itineraries i WHERE i.deleted_at = 0 AND ` + listedItinerarySQL("i", viewerParam(1))
+	err := c.DB.QueryRowContext(ctx, totalQuery, req.ViewerId).Scan(&total)
 	if err != nil {
 		return nil, err
 	}
 
 	itinerariesQuery := `
-        SELECT id, title, description, start_date, end_date, author_id, created_at
-        FROM itineraries
-        WHERE deleted_at = 0
-        ORDER BY created_at DESC
+        SELECT i.id, i.title, i.description, i.start_date, i.end_date, i.author_id, i.created_at, i.visibility
+        FROM itineraries i
+        WHERE i.deleted_at = 0 AND ` + listedItinerarySQL("i", viewerParam(3)) + `
+        ORDER BY i.created_at DESC
         LIMIT $1 OFFSET $2
     `
-	rows, err := c.DB.QueryContext(ctx, itinerariesQuery, req.Limit, req.Offset)
+	rows, err := c.DB.QueryContext(ctx, itinerariesQuery, req.Limit, req.Offset, req.ViewerId)
 	if err != nil {
 		return nil, err
 	}
@@ -483,6 +693,7 @@ func (c *ContentRepo) GetItineraries(ctx context.Context, req *pb.GetItineraries
 			&itinerary.EndDate,
 			&itinerary.UserId,
 			&itinerary.CreatedAt,
+			&itinerary.Visibility,
 		)
 		if err != nil {
 			return nil, err
@@ -504,14 +715,15 @@ func (c *ContentRepo) GetItineraries(ctx context.Context, req *pb.GetItineraries
 	return response, nil
 }
 
-func (c *ContentRepo) GetItinerariesById(ctx context.Context, req *pb.StoryId) (*pb.GetItinerariesByIdRes, error) {
+func (c *ContentRepo) GetItinerariesById(ctx context.Context, req *pb.GetItinerariesByIdReq) (*pb.GetItinerariesByIdRes, error) {
 
 	itinerary := pb.GetItinerariesByIdRes{
 		Author: &pb.Author{},
 	}
 
 	itineraryQuery := `
-        SELECT i.id, i.title, i.description, i.start_date, i.end_date, u.id, u.username, u.full_name
+        SELECT i.id, i.title, i.description, i.start_date, i.end_date, i.visibility, i.version,
+               ` + savedCountSQL("'itinerary'", "i.id") + `, u.id, u.username, u.full_name
         FROM itineraries i
         JOIN users u ON i.author_id = u.id
         WHERE i.id = $1 AND i.deleted_at = 0
@@ -522,6 +734,9 @@ func (c *ContentRepo) GetItinerariesById(ctx context.Context, req *pb.StoryId) (
 		&itinerary.Description,
 		&itinerary.StartDate,
 		&itinerary.EndDate,
+		&itinerary.Visibility,
+		&itinerary.Version,
+		&itinerary.SavedCount,
 		&itinerary.Author.UserId,
 		&itinerary.Author.Username,
 		&itinerary.Author.FullName,
@@ -531,9 +746,11 @@ func (c *ContentRepo) GetItinerariesById(ctx context.Context, req *pb.StoryId) (
 	}
 
 	destinationsQuery := `
-        SELECT name, start_date, end_date
+        SELECT id, name, start_date, end_date, destination_id, latitude, longitude,
+               coalesce(last_modified_by::TEXT, ''), updated_at
         FROM itinerary_destinations
         WHERE itinerary_id = $1
+        ORDER BY start_date
     `
 	rows, err := c.DB.QueryContext(ctx, destinationsQuery, itinerary.Id)
 	if err != nil {
@@ -544,25 +761,35 @@ func (c *ContentRepo) GetItinerariesById(ctx context.Context, req *pb.StoryId) (
 	var destinations []*pb.Destination
 	for rows.Next() {
 		var destination pb.Destination
+		var catalogueID sql.NullString
+		var latitude, longitude sql.NullFloat64
 		err := rows.Scan(
+			&destination.Id,
 			&destination.Name,
 			&destination.StartDate,
 			&destination.EndDate,
+			&catalogueID,
+			&latitude,
+			&longitude,
+			&destination.LastModifiedBy,
+			&destination.UpdatedAt,
 		)
 		if err != nil {
 			return nil, err
 		}
+		destination.DestinationId = catalogueID.String
+		destination.Latitude = nullFloatPtr(latitude)
+		destination.Longitude = nullFloatPtr(longitude)
 
 		activitiesQuery := `
-            SELECT activity
+            SELECT id, activity, coalesce(day_index, 0), coalesce(to_char(start_time, 'HH24:MI'), ''),
+                   coalesce(duration_minutes, 0), position, coalesce(cost::TEXT, ''), coalesce(category, ''),
+                   coalesce(last_modified_by::TEXT, ''), updated_at
             FROM itinerary_activities
-            WHERE destination_id in (
-                SELECT id
-                FROM itinerary_destinations
-                WHERE name = $1
-            )
+            WHERE destination_id = $1
+            ORDER BY day_index NULLS LAST, position
         `
-		activityRows, err := c.DB.QueryContext(ctx, activitiesQuery, destination.Name)
+		activityRows, err := c.DB.QueryContext(ctx, activitiesQuery, destination.Id)
 		if err != nil {
 			return nil, err
 		}
@@ -571,7 +798,9 @@ func (c *ContentRepo) GetItinerariesById(ctx context.Context, req *pb.StoryId) (
 		var activities []*pb.Activities
 		for activityRows.Next() {
 			var activity pb.Activities
-			err := activityRows.Scan(&activity.Text)
+			err := activityRows.Scan(&activity.Id, &activity.Text, &activity.DayIndex, &activity.StartTime,
+				&activity.DurationMinutes, &activity.Position, &activity.Cost, &activity.Category,
+				&activity.LastModifiedBy, &activity.UpdatedAt)
 			if err != nil {
 				return nil, err
 			}
@@ -592,6 +821,16 @@ func (c *ContentRepo) GetItinerariesById(ctx context.Context, req *pb.StoryId) (
 
 	itinerary.Destination = destinations
 
+	itinerary.Collaborators, err = c.getCollaborators(ctx, itinerary.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	itinerary.Media, err = getItemMedia(ctx, c.DB, ItemItinerary, itinerary.Id)
+	if err != nil {
+		return nil, err
+	}
+
 	return &itinerary, nil
 }
 
@@ -620,7 +859,7 @@ func (c *ContentRepo) CommentItineraries(ctx context.Context, req *pb.CommentIti
 func (c *ContentRepo) GetDestinations(ctx context.Context, req *pb.GetDestinationsReq) (*pb.GetDestinationsRes, error) {
 
 	query := `
-        SELECT id, name, country, description, currency
+        SELECT id, name, country, description, currency, latitude, longitude
         FROM destinations
         WHERE ($1 = '' OR name ILIKE '%' || $1 || '%')
         ORDER BY name
@@ -636,15 +875,20 @@ func (c *ContentRepo) GetDestinations(ctx context.Context, req *pb.GetDestinatio
 	var destinations []*pb.Destinations
 	for rows.Next() {
 		var destination pb.Destinations
+		var latitude, longitude sql.NullFloat64
 		if err := rows.Scan(
 			&destination.Id,
 			&destination.Name,
 			&destination.Country,
 			&destination.Description,
 			&destination.Currency,
+			&latitude,
+			&longitude,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan destination row: %v", err)
 		}
+		destination.Latitude = nullFloatPtr(latitude)
+		destination.Longitude = nullFloatPtr(longitude)
 		destinations = append(destinations, &destination)
 	}
 
@@ -672,12 +916,14 @@ func (c *ContentRepo) GetDestinations(ctx context.Context, req *pb.GetDestinatio
 func (c *ContentRepo) GetDestinationsById(ctx context.Context, req *pb.GetDestinationsByIdReq) (*pb.GetDestinationsByIdRes, error) {
 
 	query := `
-        SELECT id, name, country, description, best_time_to_visit, average_cost_per_day, currency, language
+        SELECT id, name, country, description, best_time_to_visit, average_cost_per_day, currency, language,
+               latitude, longitude
         FROM destinations
         WHERE id = $1
     `
 
 	var destination pb.GetDestinationsByIdRes
+	var latitude, longitude sql.NullFloat64
 	err := c.DB.QueryRowContext(ctx, query, req.Id).Scan(
 		&destination.Id,
 		&destination.Name,
@@ -687,10 +933,29 @@ func (c *ContentRepo) GetDestinationsById(ctx context.Context, req *pb.GetDestin
 		&destination.AverageCostPerDay,
 		&destination.Currency,
 		&destination.Language,
+		&latitude,
+		&longitude,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch destination by ID: %v", err)
 	}
+	destination.Latitude = nullFloatPtr(latitude)
+	destination.Longitude = nullFloatPtr(longitude)
+
+	limit := req.RecentLimit
+	if limit <= 0 {
+		limit = defaultRecentLimit
+	}
+
+	destination.RecentStories, err = c.getDestinationStories(ctx, destination.Id, req.ViewerId, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	destination.RecentItineraries, err = c.getDestinationItineraries(ctx, destination.Id, req.ViewerId, limit)
+	if err != nil {
+		return nil, err
+	}
 
 	return &destination, nil
 }
@@ -801,7 +1066,8 @@ func (c *ContentRepo) CreateTips(ctx context.Context, req *pb.CreateTipsReq) (*p
 
 func (c *ContentRepo) GetTips(ctx context.Context, req *pb.GetTipsReq) (*pb.GetTipsRes, error) {
 	query := `
-        SELECT tt.id, tt.title, tt.category, u.id AS user_id, u.username, u.full_name
+        SELECT tt.id, tt.title, tt.category, u.id AS user_id, u.username, u.full_name,
+               ` + savedCountSQL("'tip'", "tt.id") + `
         FROM travel_tips tt
         JOIN users u ON tt.author_id = u.id
     `
@@ -832,7 +1098,8 @@ func (c *ContentRepo) GetTips(ctx context.Context, req *pb.GetTipsReq) (*pb.GetT
 	var tips []*pb.Tips
 	for rows.Next() {
 		var tipID, title, category, userID, username, fullName string
-		if err := rows.Scan(&tipID, &title, &category, &userID, &username, &fullName); err != nil {
+		var savedCount int64
+		if err := rows.Scan(&tipID, &title, &category, &userID, &username, &fullName, &savedCount); err != nil {
 			return nil, err
 		}
 
@@ -843,10 +1110,11 @@ func (c *ContentRepo) GetTips(ctx context.Context, req *pb.GetTipsReq) (*pb.GetT
 		}
 
 		tip := &pb.Tips{
-			Id:       tipID,
-			Title:    title,
-			Category: category,
-			Author:   author,
+			Id:         tipID,
+			Title:      title,
+			Category:   category,
+			Author:     author,
+			SavedCount: savedCount,
 		}
 
 		tips = append(tips, tip)
@@ -969,10 +1237,22 @@ func (c *ContentRepo) GetUserStat(ctx context.Context, req *pb.GetUserStatReq) (
 		res.TotalCommentsReceived = "0"
 	}
 
+	viewsQuery := `
+        SELECT coalesce(SUM(views_count), 0)
+        FROM stories
+        WHERE author_id = $1 AND deleted_at = 0
+    `
+	var totalViewsReceived int64
+	err = c.DB.QueryRowContext(ctx, viewsQuery, req.UserId).Scan(&totalViewsReceived)
+	if err != nil {
+		return nil, err
+	}
+	res.TotalViewsReceived = fmt.Sprintf("%d", totalViewsReceived)
+
 	popularStoryQuery := `
         SELECT id, title, likes_count
         FROM stories
-        WHERE author_id = $1 AND deleted_at = 0
+        WHERE author_id = $1 AND deleted_at = 0 AND visibility = 'public' AND status = 'published'
         ORDER BY likes_count DESC
         LIMIT 1
     `
@@ -992,7 +1272,7 @@ func (c *ContentRepo) GetUserStat(ctx context.Context, req *pb.GetUserStatReq) (
 	popularItineraryQuery := `
         SELECT id, title, likes_count
         FROM itineraries
-        WHERE author_id = $1 AND deleted_at = 0
+        WHERE author_id = $1 AND deleted_at = 0 AND visibility = 'public'
         ORDER BY likes_count DESC
         LIMIT 1
     `
@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	pb "Content-Service/genproto/content"
+	"context"
+	"testing"
+)
+
+// newTestRepo returns a repository on the configured database, skipping the
+// test when it is unavailable.
+func newTestRepo(t *testing.T) *ContentRepo {
+	t.Helper()
+	db, err := ConnectDB()
+	if err != nil {
+		t.Skipf("database unavailable: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return NewContentRepository(db)
+}
+
+// createTestUser inserts a user with a random username and returns its id.
+func createTestUser(t *testing.T, c *ContentRepo) string {
+	t.Helper()
+	query := `
+        INSERT INTO users (username, email, password, full_name)
+        SELECT name, name || '@example.com', 'secret', 'Test User'
+        FROM (SELECT 'test_' || substr(md5(random()::TEXT), 1, 16) AS name) n
+        RETURNING id
+    `
+	var id string
+	if err := c.DB.QueryRow(query).Scan(&id); err != nil {
+		t.Fatalf("failed to create test user: %v", err)
+	}
+	return id
+}
+
+// createTestStory creates a published public story from req, filling in
+// what it leaves empty.
+func createTestStory(t *testing.T, c *ContentRepo, req *pb.CreateStoriesRequest) *pb.CreateStoriesResponse {
+	t.Helper()
+	if req.Title == "" {
+		req.Title = "Test story"
+	}
+	if req.Content == "" {
+		req.Content = req.Title
+	}
+	if req.Status == "" {
+		req.Status = StatusPublished
+	}
+	story, err := c.CreateStory(context.Background(), req)
+	if err != nil {
+		t.Fatalf("failed to create test story: %v", err)
+	}
+	return story
+}
+
+// createTestItinerary creates an itinerary of authorID with one destination.
+func createTestItinerary(t *testing.T, c *ContentRepo, authorID string) *pb.ItinerariesRes {
+	t.Helper()
+	itinerary, err := c.Itineraries(context.Background(), &pb.ItinerariesReq{
+		UserId:    authorID,
+		Title:     "Test itinerary",
+		StartDate: "2024-06-01",
+		EndDate:   "2024-06-10",
+		Destinations: []*pb.Destination{
+			{Name: "Samarkand", StartDate: "2024-06-01", EndDate: "2024-06-05"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create test itinerary: %v", err)
+	}
+	return itinerary
+}
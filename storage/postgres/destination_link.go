@@ -0,0 +1,194 @@
+package postgres
+
+import (
+	pb "Content-Service/genproto/content"
+	"Content-Service/help"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// ErrDestinationNotFound is returned when a client-supplied destination_id
+// does not refer to a destination in the catalogue.
+var ErrDestinationNotFound = errors.New("destination not found")
+
+// destinationMatchThreshold is the minimum trigram score for free text to be
+// linked to a catalogue destination when the names are not an exact match.
+const destinationMatchThreshold = 0.45
+
+const defaultRecentLimit = 5
+
+type queryRower interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// destinationMatchSQL returns a subquery selecting the id of the catalogue
+// destination that best matches the text expression expr, or no row.
+func destinationMatchSQL(expr string) string {
+	score := fmt.Sprintf("greatest(similarity(lower(d.name), lower(%[1]s)), word_similarity(lower(d.name), lower(%[1]s)))", expr)
+
+	return fmt.Sprintf(`
+        SELECT d.id
+        FROM destinations d
+        WHERE d.deleted_at = 0 AND trim(%[1]s) <> ''
+          AND (lower(d.name) = lower(trim(%[1]s)) OR %[2]s >= %[3]g)
+        ORDER BY lower(d.name) = lower(trim(%[1]s)) DESC, %[2]s DESC, d.popularity_score DESC NULLS LAST
+        LIMIT 1
+    `, expr, score, destinationMatchThreshold)
+}
+
+// linkDestination returns destinationID when the client supplied one and
+// otherwise the catalogue destination matching text, if any. It returns
+// ErrDestinationNotFound when destinationID is not in the catalogue.
+func linkDestination(ctx context.Context, q queryRower, destinationID, text string) (sql.NullString, error) {
+	if destinationID != "" {
+		if !help.IsUUID(destinationID) {
+			return sql.NullString{}, ErrDestinationNotFound
+		}
+		var id string
+		err := q.QueryRowContext(ctx, `SELECT id FROM destinations WHERE id = $1 AND deleted_at = 0`, destinationID).Scan(&id)
+		if err == sql.ErrNoRows {
+			return sql.NullString{}, ErrDestinationNotFound
+		}
+		if err != nil {
+			return sql.NullString{}, fmt.Errorf("failed to check destination: %v", err)
+		}
+		return sql.NullString{String: id, Valid: true}, nil
+	}
+
+	var id sql.NullString
+	err := q.QueryRowContext(ctx, destinationMatchSQL("$1::TEXT"), text).Scan(&id)
+	if err == sql.ErrNoRows {
+		return sql.NullString{}, nil
+	}
+	if err != nil {
+		return sql.NullString{}, fmt.Errorf("failed to match destination: %v", err)
+	}
+
+	return id, nil
+}
+
+// BackfillDestinationLinks links existing stories and itinerary destinations
+// that have no destination_id to the catalogue and reports how many rows of
+// each were linked.
+func (c *ContentRepo) BackfillDestinationLinks(ctx context.Context) (int64, int64, error) {
+	storiesQuery := fmt.Sprintf(`
+        WITH matches AS (
+            SELECT s.id, (%s) AS destination_id
+            FROM stories s
+            WHERE s.destination_id IS NULL AND s.deleted_at = 0 AND coalesce(s.location, '') <> ''
+        )
+        UPDATE stories s
+        SET destination_id = m.destination_id
+        FROM matches m
+        WHERE s.id = m.id AND m.destination_id IS NOT NULL
+    `, destinationMatchSQL("s.location"))
+
+	result, err := c.DB.ExecContext(ctx, storiesQuery)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to backfill story destinations: %v", err)
+	}
+	stories, err := result.RowsAffected()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	itinerariesQuery := fmt.Sprintf(`
+        WITH matches AS (
+            SELECT i.id, (%s) AS destination_id
+            FROM itinerary_destinations i
+            WHERE i.destination_id IS NULL
+        )
+        UPDATE itinerary_destinations i
+        SET destination_id = m.destination_id
+        FROM matches m
+        WHERE i.id = m.id AND m.destination_id IS NOT NULL
+    `, destinationMatchSQL("i.name"))
+
+	result, err = c.DB.ExecContext(ctx, itinerariesQuery)
+	if err != nil {
+		return stories, 0, fmt.Errorf("failed to backfill itinerary destinations: %v", err)
+	}
+	destinations, err := result.RowsAffected()
+	if err != nil {
+		return stories, 0, err
+	}
+
+	return stories, destinations, nil
+}
+
+func (c *ContentRepo) getDestinationStories(ctx context.Context, destinationID, viewerID string, limit int64) ([]*pb.Stories, error) {
+	query := `
+        SELECT ` + storySummaryColumns + `
+        FROM stories s
+        JOIN users u ON s.author_id = u.id
+        WHERE s.destination_id = $1 AND s.deleted_at = 0 AND ` + listedStorySQL("s", viewerParam(3)) + `
+        ORDER BY s.created_at DESC
+        LIMIT $2
+    `
+	rows, err := c.DB.QueryContext(ctx, query, destinationID, limit, viewerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch destination stories: %v", err)
+	}
+	defer rows.Close()
+
+	var stories []*pb.Stories
+	for rows.Next() {
+		story, err := scanStorySummary(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan destination story row: %v", err)
+		}
+		stories = append(stories, story)
+	}
+
+	return stories, rows.Err()
+}
+
+func (c *ContentRepo) getDestinationItineraries(ctx context.Context, destinationID, viewerID string, limit int64) ([]*pb.ItinerariesRes, error) {
+	query := `
+        SELECT i.id, i.title, i.description, i.start_date, i.end_date, i.author_id, i.created_at, i.visibility
+        FROM itineraries i
+        WHERE i.deleted_at = 0 AND ` + listedItinerarySQL("i", viewerParam(3)) + ` AND EXISTS (
+            SELECT 1
+            FROM itinerary_destinations d
+            WHERE d.itinerary_id = i.id AND d.destination_id = $1
+        )
+        ORDER BY i.created_at DESC
+        LIMIT $2
+    `
+	rows, err := c.DB.QueryContext(ctx, query, destinationID, limit, viewerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch destination itineraries: %v", err)
+	}
+	defer rows.Close()
+
+	var itineraries []*pb.ItinerariesRes
+	for rows.Next() {
+		var itinerary pb.ItinerariesRes
+		err := rows.Scan(
+			&itinerary.Id,
+			&itinerary.Title,
+			&itinerary.Description,
+			&itinerary.StartDate,
+			&itinerary.EndDate,
+			&itinerary.UserId,
+			&itinerary.CreatedAt,
+			&itinerary.Visibility,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan destination itinerary row: %v", err)
+		}
+		itineraries = append(itineraries, &itinerary)
+	}
+
+	return itineraries, rows.Err()
+}
@@ -0,0 +1,33 @@
+package postgres
+
+import (
+	pb "Content-Service/genproto/content"
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestLinkDestinationChecksSuppliedID(t *testing.T) {
+	c := newTestRepo(t)
+	ctx := context.Background()
+	author := createTestUser(t, c)
+
+	var known string
+	err := c.DB.QueryRow(`INSERT INTO destinations (name, country) VALUES ('Test destination', 'Nowhere') RETURNING id`).Scan(&known)
+	if err != nil {
+		t.Fatal(err)
+	}
+	story := createTestStory(t, c, &pb.CreateStoriesRequest{UserId: author, DestinationId: known})
+	if story.DestinationId != known {
+		t.Errorf("destination_id = %q, want %q", story.DestinationId, known)
+	}
+
+	for _, id := range []string{"00000000-0000-0000-0000-000000000000", "not-a-uuid"} {
+		_, err := c.CreateStory(ctx, &pb.CreateStoriesRequest{
+			Title: "Test story", Content: "Test story", UserId: author, Status: StatusPublished, DestinationId: id,
+		})
+		if !errors.Is(err, ErrDestinationNotFound) {
+			t.Errorf("CreateStory with destination_id %q = %v, want ErrDestinationNotFound", id, err)
+		}
+	}
+}
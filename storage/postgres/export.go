@@ -0,0 +1,49 @@
+package postgres
+
+import (
+	pb "Content-Service/genproto/content"
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// StreamGeotaggedStories calls fn for every live story of authorID that has
+// coordinates and is listed for viewerID, oldest first. When destinationIDs
+// is not empty only stories linked to one of those catalogue destinations
+// are included.
+func (c *ContentRepo) StreamGeotaggedStories(ctx context.Context, authorID, viewerID string, destinationIDs []string, fn func(*pb.GetStoryRes) error) error {
+	query := `
+        SELECT s.id, s.title, s.location, s.created_at, s.latitude, s.longitude
+        FROM stories s
+        WHERE s.author_id = $1 AND s.deleted_at = 0
+          AND s.latitude IS NOT NULL AND s.longitude IS NOT NULL
+          AND (coalesce(cardinality($2::UUID[]), 0) = 0 OR s.destination_id = ANY($2::UUID[]))
+          AND ` + listedStorySQL("s", viewerParam(3)) + `
+        ORDER BY s.created_at
+    `
+	rows, err := c.DB.QueryContext(ctx, query, authorID, pq.Array(destinationIDs), viewerID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch geotagged stories: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var story pb.GetStoryRes
+		var location sql.NullString
+		var latitude, longitude float64
+		if err := rows.Scan(&story.Id, &story.Title, &location, &story.CreatedAt, &latitude, &longitude); err != nil {
+			return fmt.Errorf("failed to scan geotagged story row: %v", err)
+		}
+		story.Location = location.String
+		story.Latitude = &latitude
+		story.Longitude = &longitude
+
+		if err := fn(&story); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
@@ -0,0 +1,27 @@
+package postgres
+
+import (
+	pb "Content-Service/genproto/content"
+	"context"
+	"testing"
+)
+
+func TestStreamGeotaggedStoriesWithoutDestinations(t *testing.T) {
+	c := newTestRepo(t)
+	author := createTestUser(t, c)
+	latitude, longitude := 41.31, 69.28
+	story := createTestStory(t, c, &pb.CreateStoriesRequest{UserId: author, Latitude: &latitude, Longitude: &longitude})
+	createTestStory(t, c, &pb.CreateStoriesRequest{UserId: author})
+
+	var ids []string
+	err := c.StreamGeotaggedStories(context.Background(), author, "", nil, func(s *pb.GetStoryRes) error {
+		ids = append(ids, s.Id)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 1 || ids[0] != story.Id {
+		t.Errorf("exported stories = %v, want only the geotagged story %s", ids, story.Id)
+	}
+}
@@ -0,0 +1,274 @@
+package postgres
+
+import (
+	pb "Content-Service/genproto/content"
+	"Content-Service/help"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// FeedRef points to a feed item of an author at the time it entered the
+// feed: when a story was published or an itinerary created.
+type FeedRef struct {
+	ItemType string
+	ItemID   string
+	AuthorID string
+	At       time.Time
+}
+
+// FeedEntry is a feed item with its position in the feed.
+type FeedEntry struct {
+	Cursor help.FeedCursor
+	Item   *pb.FeedItem
+}
+
+// followedSQL matches rows of alias written by authors the user bound to
+// $1 follows and listed for them.
+func followedSQL(alias string, listed func(alias, viewer string) string) string {
+	return fmt.Sprintf(`EXISTS (SELECT 1 FROM followers f WHERE f.follower_id = $1 AND f.following_id = %[1]s.author_id)
+              AND %[2]s`, alias, listed(alias, viewerParam(1)))
+}
+
+// feedAfterSQL matches rows whose feed position, given by the time
+// expression at, comes after the cursor bound to $2, $3 and $4. A NULL
+// time in $2 means the start of the feed.
+func feedAfterSQL(at, itemType, id string) string {
+	return fmt.Sprintf(`($2::TIMESTAMPTZ IS NULL OR (%s, '%s'::TEXT, %s) < ($2, $3::TEXT, NULLIF($4, '')::UUID))`, at, itemType, id)
+}
+
+// pulledSQL matches rows of alias that the user bound to $1 has to read
+// from Postgres: every row when the parameter n is true, otherwise those not
+// on the user's timeline because they were never fanned out or the user
+// followed the author after they were.
+func pulledSQL(alias string, n int) string {
+	return fmt.Sprintf(`($%[2]d::BOOLEAN OR NOT EXISTS (
+              SELECT 1 FROM followers fp
+              WHERE fp.follower_id = $1 AND fp.following_id = %[1]s.author_id AND fp.followed_at <= %[1]s.fanned_out_at))`, alias, n)
+}
+
+// GetFeed returns up to limit stories and up to limit itineraries from the
+// authors userID follows that come after the cursor, newest first. Unless
+// all is true, items fanned out to the user's timeline are left out.
+func (c *ContentRepo) GetFeed(ctx context.Context, userID string, after help.FeedCursor, limit int64, all bool) ([]FeedEntry, error) {
+	args := append(feedArgs(userID, after), all, limit)
+	stories, err := c.feedStories(ctx, pulledSQL("s", 5), "LIMIT $6", args...)
+	if err != nil {
+		return nil, err
+	}
+	itineraries, err := c.feedItineraries(ctx, pulledSQL("i", 5), "LIMIT $6", args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(stories, itineraries...), nil
+}
+
+// GetFeedEntries returns the referenced items that are still in the feed of
+// userID and come after the cursor, in no particular order.
+func (c *ContentRepo) GetFeedEntries(ctx context.Context, userID string, after help.FeedCursor, refs []FeedRef) ([]FeedEntry, error) {
+	var storyIDs, itineraryIDs []string
+	for _, ref := range refs {
+		switch ref.ItemType {
+		case ItemStory:
+			storyIDs = append(storyIDs, ref.ItemID)
+		case ItemItinerary:
+			itineraryIDs = append(itineraryIDs, ref.ItemID)
+		}
+	}
+
+	var entries []FeedEntry
+	if len(storyIDs) > 0 {
+		stories, err := c.feedStories(ctx, `s.id = ANY($5::UUID[])`, "", append(feedArgs(userID, after), pq.Array(storyIDs))...)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, stories...)
+	}
+	if len(itineraryIDs) > 0 {
+		itineraries, err := c.feedItineraries(ctx, `i.id = ANY($5::UUID[])`, "", append(feedArgs(userID, after), pq.Array(itineraryIDs))...)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, itineraries...)
+	}
+
+	return entries, nil
+}
+
+func feedArgs(userID string, after help.FeedCursor) []interface{} {
+	at := sql.NullTime{Time: after.Time, Valid: !after.IsZero()}
+	return []interface{}{userID, at, after.ItemType, after.ItemID}
+}
+
+// feedStories runs the feed query for stories with an extra condition and
+// an optional LIMIT clause.
+func (c *ContentRepo) feedStories(ctx context.Context, condition, limit string, args ...interface{}) ([]FeedEntry, error) {
+	query := `
+        SELECT s.published_at, ` + storySummaryColumns + `
+        FROM stories s
+        JOIN users u ON s.author_id = u.id
+        WHERE s.deleted_at = 0 AND s.status = 'published' AND ` + followedSQL("s", listedStorySQL) + `
+          AND ` + feedAfterSQL("s.published_at", ItemStory, "s.id") + `
+          AND ` + condition + `
+        ORDER BY s.published_at DESC, s.id DESC
+        ` + limit
+	rows, err := c.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch feed stories: %v", err)
+	}
+	defer rows.Close()
+
+	var entries []FeedEntry
+	for rows.Next() {
+		var at time.Time
+		story, err := scanStorySummary(prefixScanner{rows, []interface{}{&at}})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan feed story row: %v", err)
+		}
+		entries = append(entries, FeedEntry{
+			Cursor: help.FeedCursor{Time: at, ItemType: ItemStory, ItemID: story.StoryId},
+			Item:   &pb.FeedItem{ItemType: ItemStory, Story: story},
+		})
+	}
+
+	return entries, rows.Err()
+}
+
+// feedItineraries is feedStories for itineraries, which enter the feed when
+// they are created.
+func (c *ContentRepo) feedItineraries(ctx context.Context, condition, limit string, args ...interface{}) ([]FeedEntry, error) {
+	query := `
+        SELECT i.created_at, i.id, i.title, i.description, i.start_date, i.end_date, i.author_id, i.created_at, i.visibility, i.version
+        FROM itineraries i
+        WHERE i.deleted_at = 0 AND ` + followedSQL("i", listedItinerarySQL) + `
+          AND ` + feedAfterSQL("i.created_at", ItemItinerary, "i.id") + `
+          AND ` + condition + `
+        ORDER BY i.created_at DESC, i.id DESC
+        ` + limit
+	rows, err := c.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch feed itineraries: %v", err)
+	}
+	defer rows.Close()
+
+	var entries []FeedEntry
+	for rows.Next() {
+		var at time.Time
+		var itinerary pb.ItinerariesRes
+		err := rows.Scan(
+			&at,
+			&itinerary.Id,
+			&itinerary.Title,
+			&itinerary.Description,
+			&itinerary.StartDate,
+			&itinerary.EndDate,
+			&itinerary.UserId,
+			&itinerary.CreatedAt,
+			&itinerary.Visibility,
+			&itinerary.Version,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan feed itinerary row: %v", err)
+		}
+		entries = append(entries, FeedEntry{
+			Cursor: help.FeedCursor{Time: at, ItemType: ItemItinerary, ItemID: itinerary.Id},
+			Item:   &pb.FeedItem{ItemType: ItemItinerary, Itinerary: &itinerary},
+		})
+	}
+
+	return entries, rows.Err()
+}
+
+// FeedRefs returns where the given stories or itineraries sit in their
+// author's followers' feeds. Stories that are not published are left out.
+func (c *ContentRepo) FeedRefs(ctx context.Context, itemType string, ids []string) ([]FeedRef, error) {
+	query := `SELECT s.id, s.author_id, s.published_at FROM stories s WHERE s.id = ANY($1::UUID[]) AND s.status = 'published'`
+	if itemType == ItemItinerary {
+		query = `SELECT i.id, i.author_id, i.created_at FROM itineraries i WHERE i.id = ANY($1::UUID[])`
+	}
+	rows, err := c.DB.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch feed refs: %v", err)
+	}
+	defer rows.Close()
+
+	var refs []FeedRef
+	for rows.Next() {
+		ref := FeedRef{ItemType: itemType}
+		if err := rows.Scan(&ref.ItemID, &ref.AuthorID, &ref.At); err != nil {
+			return nil, fmt.Errorf("failed to scan feed ref row: %v", err)
+		}
+		refs = append(refs, ref)
+	}
+
+	return refs, rows.Err()
+}
+
+// FanOutAudience returns how many followers an author has and the database
+// time they were counted at.
+func (c *ContentRepo) FanOutAudience(ctx context.Context, authorID string) (int64, time.Time, error) {
+	var count int64
+	var at time.Time
+	query := `SELECT COUNT(*), CURRENT_TIMESTAMP FROM followers WHERE following_id = $1`
+	if err := c.DB.QueryRowContext(ctx, query, authorID).Scan(&count, &at); err != nil {
+		return 0, time.Time{}, fmt.Errorf("failed to count followers: %v", err)
+	}
+	return count, at, nil
+}
+
+// FollowerIDs returns up to limit followers of an author ordered by id,
+// starting after the follower id after, or from the first when it is empty.
+func (c *ContentRepo) FollowerIDs(ctx context.Context, authorID, after string, limit int) ([]string, error) {
+	query := `
+        SELECT follower_id
+        FROM followers
+        WHERE following_id = $1 AND ($2 = '' OR follower_id > NULLIF($2, '')::UUID)
+        ORDER BY follower_id
+        LIMIT $3
+    `
+	rows, err := c.DB.QueryContext(ctx, query, authorID, after, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch followers: %v", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan follower row: %v", err)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// MarkFannedOut records that a feed item reached the timelines of the
+// followers its author had at the given time. The feed query then leaves it
+// to the timelines for those followers.
+func (c *ContentRepo) MarkFannedOut(ctx context.Context, ref FeedRef, at time.Time) error {
+	table, err := itemTable(ref.ItemType)
+	if err != nil {
+		return err
+	}
+	if _, err := c.DB.ExecContext(ctx, `UPDATE `+table+` SET fanned_out_at = $2 WHERE id = $1`, ref.ItemID, at); err != nil {
+		return fmt.Errorf("failed to mark feed item as fanned out: %v", err)
+	}
+	return nil
+}
+
+// prefixScanner scans the leading columns of a row into dest and hands the
+// rest to a scan function written for the remaining columns.
+type prefixScanner struct {
+	row  interface{ Scan(...interface{}) error }
+	dest []interface{}
+}
+
+func (p prefixScanner) Scan(dest ...interface{}) error {
+	return p.row.Scan(append(p.dest, dest...)...)
+}
@@ -0,0 +1,345 @@
+package postgres
+
+import (
+	pb "Content-Service/genproto/content"
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+const itineraryDestinationColumns = `
+        id, name, start_date, end_date, coalesce(destination_id::TEXT, ''), latitude, longitude,
+        coalesce(last_modified_by::TEXT, ''), updated_at`
+
+const itineraryActivityColumns = `
+        id, activity, coalesce(day_index, 0), coalesce(to_char(start_time, 'HH24:MI'), ''),
+        coalesce(duration_minutes, 0), position, coalesce(cost::TEXT, ''), coalesce(category, ''),
+        coalesce(last_modified_by::TEXT, ''), updated_at`
+
+func scanItineraryDestination(row interface{ Scan(...interface{}) error }) (*pb.Destination, error) {
+	var destination pb.Destination
+	var latitude, longitude sql.NullFloat64
+	err := row.Scan(
+		&destination.Id,
+		&destination.Name,
+		&destination.StartDate,
+		&destination.EndDate,
+		&destination.DestinationId,
+		&latitude,
+		&longitude,
+		&destination.LastModifiedBy,
+		&destination.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	destination.Latitude = nullFloatPtr(latitude)
+	destination.Longitude = nullFloatPtr(longitude)
+	return &destination, nil
+}
+
+func scanItineraryActivity(row interface{ Scan(...interface{}) error }) (*pb.Activities, error) {
+	var activity pb.Activities
+	err := row.Scan(&activity.Id, &activity.Text, &activity.DayIndex, &activity.StartTime,
+		&activity.DurationMinutes, &activity.Position, &activity.Cost, &activity.Category,
+		&activity.LastModifiedBy, &activity.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &activity, nil
+}
+
+// Subqueries selecting the itinerary of the destination or activity bound
+// to $1.
+const (
+	itineraryOfDestinationSQL = `SELECT itinerary_id FROM itinerary_destinations WHERE id = $1`
+	itineraryOfActivitySQL    = `
+        SELECT d.itinerary_id
+        FROM itinerary_activities a
+        JOIN itinerary_destinations d ON a.destination_id = d.id
+        WHERE a.id = $1`
+)
+
+// bumpItineraryVersion gives the itinerary selected by itinerarySQL for id a
+// new version, so an edit of its destinations or activities conflicts with
+// updates expecting the old one.
+func bumpItineraryVersion(ctx context.Context, q execer, itinerarySQL, id string) error {
+	query := `UPDATE itineraries SET version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE id = (` + itinerarySQL + `)`
+	if _, err := q.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to update itinerary version: %v", err)
+	}
+	return nil
+}
+
+// insertItineraryDestination stores dest and its activities under an
+// itinerary, recording userID as their last modifier.
+func insertItineraryDestination(ctx context.Context, q queryRower, itineraryID, userID string, dest *pb.Destination) (*pb.Destination, error) {
+	catalogueID, err := linkDestination(ctx, q, dest.DestinationId, dest.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+        INSERT INTO itinerary_destinations (itinerary_id, name, start_date, end_date, destination_id, latitude, longitude, last_modified_by)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, '')::UUID)
+        RETURNING ` + itineraryDestinationColumns
+	destination, err := scanItineraryDestination(q.QueryRowContext(ctx, query, itineraryID, dest.Name, dest.StartDate, dest.EndDate,
+		catalogueID, dest.Latitude, dest.Longitude, userID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert itinerary destination: %v", err)
+	}
+
+	for i, activity := range dest.Activities {
+		position := activity.Position
+		if position == 0 {
+			position = int64(i + 1)
+		}
+		inserted, err := insertItineraryActivity(ctx, q, destination.Id, userID, activity, position)
+		if err != nil {
+			return nil, err
+		}
+		destination.Activities = append(destination.Activities, inserted)
+	}
+
+	return destination, nil
+}
+
+// insertItineraryActivity stores an activity under an itinerary destination
+// at position, or after the existing ones when position is zero.
+func insertItineraryActivity(ctx context.Context, q queryRower, destinationID, userID string, activity *pb.Activities, position int64) (*pb.Activities, error) {
+	query := `
+        INSERT INTO itinerary_activities (destination_id, activity, day_index, start_time, duration_minutes, position, cost, category, last_modified_by)
+        VALUES ($1, $2, NULLIF($3, 0), NULLIF($4, '')::TIME, NULLIF($5, 0),
+                CASE WHEN $6::BIGINT > 0 THEN $6::BIGINT
+                     ELSE (SELECT coalesce(max(position), 0) + 1 FROM itinerary_activities WHERE destination_id = $1) END,
+                NULLIF($7, '')::DECIMAL, NULLIF($8, ''), NULLIF($9, '')::UUID)
+        RETURNING ` + itineraryActivityColumns
+	inserted, err := scanItineraryActivity(q.QueryRowContext(ctx, query, destinationID, activity.Text, activity.DayIndex,
+		activity.StartTime, activity.DurationMinutes, position, activity.Cost, activity.Category, userID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert itinerary activity: %v", err)
+	}
+
+	return inserted, nil
+}
+
+// ItineraryOfDestination returns the id of the live itinerary an itinerary
+// destination belongs to, or sql.ErrNoRows.
+func (c *ContentRepo) ItineraryOfDestination(ctx context.Context, id string) (string, error) {
+	query := `
+        SELECT i.id
+        FROM itinerary_destinations d
+        JOIN itineraries i ON d.itinerary_id = i.id
+        WHERE d.id = $1 AND i.deleted_at = 0
+    `
+	var itineraryID string
+	err := c.DB.QueryRowContext(ctx, query, id).Scan(&itineraryID)
+	if err != nil {
+		return "", err
+	}
+
+	return itineraryID, nil
+}
+
+// ItineraryOfActivity returns the ids of the live itinerary and itinerary
+// destination an activity belongs to, or sql.ErrNoRows.
+func (c *ContentRepo) ItineraryOfActivity(ctx context.Context, id string) (string, string, error) {
+	query := `
+        SELECT i.id, d.id
+        FROM itinerary_activities a
+        JOIN itinerary_destinations d ON a.destination_id = d.id
+        JOIN itineraries i ON d.itinerary_id = i.id
+        WHERE a.id = $1 AND i.deleted_at = 0
+    `
+	var itineraryID, destinationID string
+	err := c.DB.QueryRowContext(ctx, query, id).Scan(&itineraryID, &destinationID)
+	if err != nil {
+		return "", "", err
+	}
+
+	return itineraryID, destinationID, nil
+}
+
+func (c *ContentRepo) AddItineraryDestination(ctx context.Context, req *pb.AddItineraryDestinationReq) (*pb.Destination, error) {
+	tx, err := c.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	destination, err := insertItineraryDestination(ctx, tx, req.ItineraryId, req.UserId, req.Destination)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if err := bumpItineraryVersion(ctx, tx, itineraryOfDestinationSQL, destination.Id); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return destination, nil
+}
+
+// UpdateItineraryDestination replaces the name, dates and coordinates of an
+// itinerary destination. Its activities are edited separately.
+func (c *ContentRepo) UpdateItineraryDestination(ctx context.Context, req *pb.UpdateItineraryDestinationReq) (*pb.Destination, error) {
+	tx, err := c.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	dest := req.Destination
+	catalogueID, err := linkDestination(ctx, tx, dest.DestinationId, dest.Name)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	query := `
+        UPDATE itinerary_destinations
+        SET name = $2, start_date = $3, end_date = $4, destination_id = $5, latitude = $6, longitude = $7,
+            last_modified_by = NULLIF($8, '')::UUID, updated_at = CURRENT_TIMESTAMP
+        WHERE id = $1
+        RETURNING ` + itineraryDestinationColumns
+	destination, err := scanItineraryDestination(tx.QueryRowContext(ctx, query, req.Id, dest.Name, dest.StartDate, dest.EndDate,
+		catalogueID, dest.Latitude, dest.Longitude, req.UserId))
+	if err == sql.ErrNoRows {
+		tx.Rollback()
+		return nil, err
+	}
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to update itinerary destination: %v", err)
+	}
+	if err := bumpItineraryVersion(ctx, tx, itineraryOfDestinationSQL, req.Id); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return destination, nil
+}
+
+// DeleteItineraryDestination removes an itinerary destination together with
+// its activities.
+func (c *ContentRepo) DeleteItineraryDestination(ctx context.Context, req *pb.RemoveItineraryChildReq) error {
+	tx, err := c.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := bumpItineraryVersion(ctx, tx, itineraryOfDestinationSQL, req.Id); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM itinerary_activities WHERE destination_id = $1`, req.Id)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to delete itinerary activities: %v", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM itinerary_destinations WHERE id = $1`, req.Id)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to delete itinerary destination: %v", err)
+	}
+
+	return tx.Commit()
+}
+
+func (c *ContentRepo) AddItineraryActivity(ctx context.Context, req *pb.AddItineraryActivityReq) (*pb.Activities, error) {
+	tx, err := c.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	activity, err := insertItineraryActivity(ctx, tx, req.DestinationId, req.UserId, req.Activity, req.Activity.GetPosition())
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+        UPDATE itinerary_destinations
+        SET last_modified_by = NULLIF($2, '')::UUID, updated_at = CURRENT_TIMESTAMP
+        WHERE id = $1
+    `, req.DestinationId, req.UserId)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to update itinerary destination: %v", err)
+	}
+	if err := bumpItineraryVersion(ctx, tx, itineraryOfDestinationSQL, req.DestinationId); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return activity, nil
+}
+
+// UpdateItineraryActivity replaces an activity. A zero position keeps the
+// current one.
+func (c *ContentRepo) UpdateItineraryActivity(ctx context.Context, req *pb.UpdateItineraryActivityReq) (*pb.Activities, error) {
+	tx, err := c.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	activity := req.Activity
+	query := `
+        UPDATE itinerary_activities
+        SET activity = $2, day_index = NULLIF($3, 0), start_time = NULLIF($4, '')::TIME,
+            duration_minutes = NULLIF($5, 0), position = CASE WHEN $6::BIGINT > 0 THEN $6::BIGINT ELSE position END,
+            cost = NULLIF($7, '')::DECIMAL, category = NULLIF($8, ''),
+            last_modified_by = NULLIF($9, '')::UUID, updated_at = CURRENT_TIMESTAMP
+        WHERE id = $1
+        RETURNING ` + itineraryActivityColumns
+	updated, err := scanItineraryActivity(tx.QueryRowContext(ctx, query, req.Id, activity.Text, activity.DayIndex,
+		activity.StartTime, activity.DurationMinutes, activity.Position, activity.Cost, activity.Category, req.UserId))
+	if err == sql.ErrNoRows {
+		tx.Rollback()
+		return nil, err
+	}
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to update itinerary activity: %v", err)
+	}
+	if err := bumpItineraryVersion(ctx, tx, itineraryOfActivitySQL, req.Id); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+func (c *ContentRepo) DeleteItineraryActivity(ctx context.Context, req *pb.RemoveItineraryChildReq) error {
+	tx, err := c.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := bumpItineraryVersion(ctx, tx, itineraryOfActivitySQL, req.Id); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM itinerary_activities WHERE id = $1`, req.Id); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to delete itinerary activity: %v", err)
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,162 @@
+package postgres
+
+import (
+	pb "Content-Service/genproto/content"
+	"context"
+	"database/sql"
+	"testing"
+)
+
+func TestItineraryChildren(t *testing.T) {
+	c := newTestRepo(t)
+	ctx := context.Background()
+	author := createTestUser(t, c)
+	itinerary := createTestItinerary(t, c, author)
+
+	destination, err := c.AddItineraryDestination(ctx, &pb.AddItineraryDestinationReq{
+		ItineraryId: itinerary.Id,
+		UserId:      author,
+		Destination: &pb.Destination{Name: "Bukhara", StartDate: "2024-06-05", EndDate: "2024-06-10"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := c.ItineraryOfDestination(ctx, destination.Id); err != nil || got != itinerary.Id {
+		t.Errorf("ItineraryOfDestination = %q, %v, want %q", got, err, itinerary.Id)
+	}
+
+	first, err := c.AddItineraryActivity(ctx, &pb.AddItineraryActivityReq{
+		DestinationId: destination.Id, UserId: author, Activity: &pb.Activities{Text: "Old town walk"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := c.AddItineraryActivity(ctx, &pb.AddItineraryActivityReq{
+		DestinationId: destination.Id, UserId: author, Activity: &pb.Activities{Text: "Ark fortress"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Position != 1 || second.Position != 2 {
+		t.Errorf("positions = %d, %d, want 1, 2", first.Position, second.Position)
+	}
+	if first.LastModifiedBy != author {
+		t.Errorf("last_modified_by = %q, want %q", first.LastModifiedBy, author)
+	}
+
+	updated, err := c.UpdateItineraryActivity(ctx, &pb.UpdateItineraryActivityReq{
+		Id: first.Id, UserId: author, Activity: &pb.Activities{Text: "Bazaar", DurationMinutes: 90},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Text != "Bazaar" || updated.DurationMinutes != 90 || updated.Position != 1 {
+		t.Errorf("updated activity = %+v", updated)
+	}
+	gotItinerary, gotDestination, err := c.ItineraryOfActivity(ctx, first.Id)
+	if err != nil || gotItinerary != itinerary.Id || gotDestination != destination.Id {
+		t.Errorf("ItineraryOfActivity = %q, %q, %v", gotItinerary, gotDestination, err)
+	}
+
+	if err := c.DeleteItineraryActivity(ctx, &pb.RemoveItineraryChildReq{Id: first.Id, UserId: author}); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := c.ItineraryOfActivity(ctx, first.Id); err != sql.ErrNoRows {
+		t.Errorf("ItineraryOfActivity after delete = %v, want sql.ErrNoRows", err)
+	}
+	if err := c.DeleteItineraryDestination(ctx, &pb.RemoveItineraryChildReq{Id: destination.Id, UserId: author}); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := c.ItineraryOfActivity(ctx, second.Id); err != sql.ErrNoRows {
+		t.Errorf("activity of a deleted destination = %v, want sql.ErrNoRows", err)
+	}
+}
+
+func TestItineraryChildEditsBumpVersion(t *testing.T) {
+	c := newTestRepo(t)
+	ctx := context.Background()
+	author := createTestUser(t, c)
+	itinerary := createTestItinerary(t, c, author)
+	version := itinerary.Version
+	expectBump := func(edit string, err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("%s: %v", edit, err)
+		}
+		var current int64
+		if err := c.DB.QueryRow(`SELECT version FROM itineraries WHERE id = $1`, itinerary.Id).Scan(&current); err != nil {
+			t.Fatal(err)
+		}
+		if current != version+1 {
+			t.Errorf("version after %s = %d, want %d", edit, current, version+1)
+		}
+		version = current
+	}
+
+	destination, err := c.AddItineraryDestination(ctx, &pb.AddItineraryDestinationReq{
+		ItineraryId: itinerary.Id, UserId: author,
+		Destination: &pb.Destination{Name: "Khiva", StartDate: "2024-06-05", EndDate: "2024-06-10"},
+	})
+	expectBump("adding a destination", err)
+	_, err = c.UpdateItineraryDestination(ctx, &pb.UpdateItineraryDestinationReq{
+		Id: destination.Id, UserId: author,
+		Destination: &pb.Destination{Name: "Khiva", StartDate: "2024-06-06", EndDate: "2024-06-10"},
+	})
+	expectBump("updating a destination", err)
+	activity, err := c.AddItineraryActivity(ctx, &pb.AddItineraryActivityReq{
+		DestinationId: destination.Id, UserId: author, Activity: &pb.Activities{Text: "Itchan Kala"},
+	})
+	expectBump("adding an activity", err)
+	_, err = c.UpdateItineraryActivity(ctx, &pb.UpdateItineraryActivityReq{
+		Id: activity.Id, UserId: author, Activity: &pb.Activities{Text: "Itchan Kala at sunset"},
+	})
+	expectBump("updating an activity", err)
+	expectBump("removing an activity", c.DeleteItineraryActivity(ctx, &pb.RemoveItineraryChildReq{Id: activity.Id, UserId: author}))
+	expectBump("removing a destination", c.DeleteItineraryDestination(ctx, &pb.RemoveItineraryChildReq{Id: destination.Id, UserId: author}))
+}
+
+func TestItineraryActivityPositionsLeaveRequestAlone(t *testing.T) {
+	c := newTestRepo(t)
+	author := createTestUser(t, c)
+	activities := []*pb.Activities{{Text: "Registan"}, {Text: "Shah-i-Zinda"}}
+	itinerary, err := c.Itineraries(context.Background(), &pb.ItinerariesReq{
+		UserId:    author,
+		Title:     "Test itinerary",
+		StartDate: "2024-06-01",
+		EndDate:   "2024-06-10",
+		Destinations: []*pb.Destination{
+			{Name: "Samarkand", StartDate: "2024-06-01", EndDate: "2024-06-05", Activities: activities},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, activity := range activities {
+		if activity.Position != 0 {
+			t.Errorf("request activity %d position = %d, want it left at 0", i, activity.Position)
+		}
+	}
+
+	rows, err := c.DB.Query(`
+        SELECT a.position
+        FROM itinerary_activities a
+        JOIN itinerary_destinations d ON a.destination_id = d.id
+        WHERE d.itinerary_id = $1
+        ORDER BY a.position`, itinerary.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	var positions []int64
+	for rows.Next() {
+		var position int64
+		if err := rows.Scan(&position); err != nil {
+			t.Fatal(err)
+		}
+		positions = append(positions, position)
+	}
+	if len(positions) != 2 || positions[0] != 1 || positions[1] != 2 {
+		t.Errorf("stored positions = %v, want [1 2]", positions)
+	}
+}
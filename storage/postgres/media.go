@@ -0,0 +1,224 @@
+package postgres
+
+import (
+	pb "Content-Service/genproto/content"
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrMediaNotFound is returned when an attachment refers to media that does
+// not exist or was uploaded by someone else.
+var ErrMediaNotFound = errors.New("media not found")
+
+const mediaColumns = `
+        m.id, m.owner_id, m.content_type, m.size_bytes, m.width, m.height, m.thumbnail_key IS NOT NULL, m.created_at`
+
+// MediaRef is a story or itinerary an upload is attached to.
+type MediaRef struct {
+	ItemType string
+	ItemID   string
+}
+
+func scanMedia(row interface{ Scan(...interface{}) error }) (*pb.Media, error) {
+	var m pb.Media
+	err := row.Scan(&m.Id, &m.OwnerId, &m.ContentType, &m.SizeBytes, &m.Width, &m.Height, &m.HasThumbnail, &m.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// CreateMedia records an upload whose original and, when thumbnailKey is
+// not empty, thumbnail have already been written to blob storage.
+func (c *ContentRepo) CreateMedia(ctx context.Context, m *pb.Media, storageKey, thumbnailKey string) (*pb.Media, error) {
+	query := `
+        INSERT INTO media AS m (owner_id, content_type, size_bytes, width, height, storage_key, thumbnail_key)
+        VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''))
+        RETURNING ` + mediaColumns
+	created, err := scanMedia(c.DB.QueryRowContext(ctx, query, m.OwnerId, m.ContentType, m.SizeBytes, m.Width, m.Height,
+		storageKey, thumbnailKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create media: %v", err)
+	}
+
+	return created, nil
+}
+
+// GetMedia returns an upload with the blob keys of its original and
+// thumbnail, or sql.ErrNoRows.
+func (c *ContentRepo) GetMedia(ctx context.Context, id string) (*pb.Media, string, string, error) {
+	query := `
+        SELECT ` + mediaColumns + `, m.storage_key, coalesce(m.thumbnail_key, '')
+        FROM media m
+        WHERE m.id = $1
+    `
+	var m pb.Media
+	var storageKey, thumbnailKey string
+	err := c.DB.QueryRowContext(ctx, query, id).Scan(&m.Id, &m.OwnerId, &m.ContentType, &m.SizeBytes, &m.Width, &m.Height,
+		&m.HasThumbnail, &m.CreatedAt, &storageKey, &thumbnailKey)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	return &m, storageKey, thumbnailKey, nil
+}
+
+// MediaRefs lists the live stories and itineraries an upload is attached to.
+func (c *ContentRepo) MediaRefs(ctx context.Context, mediaID string) ([]MediaRef, error) {
+	query := `
+        SELECT 'story', s.id
+        FROM story_media sm
+        JOIN stories s ON sm.story_id = s.id
+        WHERE sm.media_id = $1 AND s.deleted_at = 0
+        UNION ALL
+        SELECT 'itinerary', i.id
+        FROM itinerary_media im
+        JOIN itineraries i ON im.itinerary_id = i.id
+        WHERE im.media_id = $1 AND i.deleted_at = 0
+    `
+	rows, err := c.DB.QueryContext(ctx, query, mediaID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch media references: %v", err)
+	}
+	defer rows.Close()
+
+	var refs []MediaRef
+	for rows.Next() {
+		var ref MediaRef
+		if err := rows.Scan(&ref.ItemType, &ref.ItemID); err != nil {
+			return nil, err
+		}
+		refs = append(refs, ref)
+	}
+
+	return refs, rows.Err()
+}
+
+// PurgeOrphanedMedia deletes up to limit uploads older than graceSeconds
+// that no story or itinerary refers to any more and returns the blob keys
+// of their originals and thumbnails. Links from trashed items still count,
+// so media is only collected once the trash purge has removed them. The
+// grace period gives fresh uploads time to be attached.
+func (c *ContentRepo) PurgeOrphanedMedia(ctx context.Context, graceSeconds int64, limit int) ([]string, int, error) {
+	query := `
+        WITH orphaned AS (
+            SELECT m.id
+            FROM media m
+            WHERE m.created_at < CURRENT_TIMESTAMP - make_interval(secs => $1)
+              AND NOT EXISTS (SELECT 1 FROM story_media sm WHERE sm.media_id = m.id)
+              AND NOT EXISTS (SELECT 1 FROM itinerary_media im WHERE im.media_id = m.id)
+            ORDER BY m.created_at
+            LIMIT $2
+            FOR UPDATE SKIP LOCKED
+        )
+        DELETE FROM media m
+        USING orphaned o
+        WHERE m.id = o.id
+        RETURNING m.storage_key, coalesce(m.thumbnail_key, '')
+    `
+	rows, err := c.DB.QueryContext(ctx, query, graceSeconds, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to purge orphaned media: %v", err)
+	}
+	defer rows.Close()
+
+	var keys []string
+	n := 0
+	for rows.Next() {
+		var storageKey, thumbnailKey string
+		if err := rows.Scan(&storageKey, &thumbnailKey); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan purged media row: %v", err)
+		}
+		keys = append(keys, storageKey)
+		if thumbnailKey != "" {
+			keys = append(keys, thumbnailKey)
+		}
+		n++
+	}
+
+	return keys, n, rows.Err()
+}
+
+// attachMedia replaces the media attached to a story or itinerary with
+// mediaIDs, in order. Every id must be an upload of ownerID; otherwise
+// ErrMediaNotFound is returned.
+func attachMedia(ctx context.Context, tx execer, itemType, itemID, ownerID string, mediaIDs []string) error {
+	table, column := "story_media", "story_id"
+	if itemType == ItemItinerary {
+		table, column = "itinerary_media", "itinerary_id"
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM `+table+` WHERE `+column+` = $1`, itemID); err != nil {
+		return fmt.Errorf("failed to detach media: %v", err)
+	}
+
+	query := `
+        INSERT INTO ` + table + ` (` + column + `, media_id, position)
+        SELECT $1, m.id, $3
+        FROM media m
+        WHERE m.id = $2 AND m.owner_id = $4
+        ON CONFLICT DO NOTHING
+    `
+	seen := make(map[string]bool)
+	for _, mediaID := range mediaIDs {
+		if seen[mediaID] {
+			continue
+		}
+		seen[mediaID] = true
+
+		result, err := tx.ExecContext(ctx, query, itemID, mediaID, len(seen), ownerID)
+		if err != nil {
+			return fmt.Errorf("failed to attach media: %v", err)
+		}
+		n, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return ErrMediaNotFound
+		}
+	}
+
+	return nil
+}
+
+// getItemMedia returns the media attached to a story or itinerary in order.
+func getItemMedia(ctx context.Context, q querier, itemType, itemID string) ([]*pb.Media, error) {
+	table, column := "story_media", "story_id"
+	if itemType == ItemItinerary {
+		table, column = "itinerary_media", "itinerary_id"
+	}
+
+	query := `
+        SELECT ` + mediaColumns + `
+        FROM ` + table + ` a
+        JOIN media m ON a.media_id = m.id
+        WHERE a.` + column + ` = $1
+        ORDER BY a.position
+    `
+	rows, err := q.QueryContext(ctx, query, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch attached media: %v", err)
+	}
+	defer rows.Close()
+
+	var media []*pb.Media
+	for rows.Next() {
+		m, err := scanMedia(rows)
+		if err != nil {
+			return nil, err
+		}
+		media = append(media, m)
+	}
+
+	return media, rows.Err()
+}
+
+func mediaIDs(media []*pb.Media) []string {
+	ids := make([]string, 0, len(media))
+	for _, m := range media {
+		ids = append(ids, m.Id)
+	}
+	return ids
+}
@@ -0,0 +1,61 @@
+package postgres
+
+import (
+	pb "Content-Service/genproto/content"
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+)
+
+func createTestMedia(t *testing.T, c *ContentRepo, ownerID, storageKey string) *pb.Media {
+	t.Helper()
+	m, err := c.CreateMedia(context.Background(), &pb.Media{
+		OwnerId: ownerID, ContentType: "image/png", SizeBytes: 1, Width: 1, Height: 1,
+	}, storageKey, storageKey+"-thumb")
+	if err != nil {
+		t.Fatalf("failed to create test media: %v", err)
+	}
+	return m
+}
+
+func TestPurgeOrphanedMedia(t *testing.T) {
+	c := newTestRepo(t)
+	ctx := context.Background()
+	owner := createTestUser(t, c)
+	orphan := createTestMedia(t, c, owner, "media/test-orphan-"+owner)
+	attached := createTestMedia(t, c, owner, "media/test-attached-"+owner)
+	createTestStory(t, c, &pb.CreateStoriesRequest{UserId: owner, MediaIds: []string{attached.Id}})
+
+	keys, _, err := c.PurgeOrphanedMedia(ctx, 24*60*60, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range keys {
+		if key == "media/test-orphan-"+owner {
+			t.Fatalf("purged an upload inside the grace period")
+		}
+	}
+
+	keys, _, err = c.PurgeOrphanedMedia(ctx, 0, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	purged := make(map[string]bool)
+	for _, key := range keys {
+		purged[key] = true
+	}
+	if !purged["media/test-orphan-"+owner] || !purged["media/test-orphan-"+owner+"-thumb"] {
+		t.Errorf("purged keys %v miss the orphaned upload and its thumbnail", keys)
+	}
+	if purged["media/test-attached-"+owner] {
+		t.Errorf("purged an attached upload")
+	}
+
+	if _, _, _, err := c.GetMedia(ctx, orphan.Id); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("GetMedia(orphan) error = %v, want sql.ErrNoRows", err)
+	}
+	if _, _, _, err := c.GetMedia(ctx, attached.Id); err != nil {
+		t.Errorf("GetMedia(attached) error = %v", err)
+	}
+}
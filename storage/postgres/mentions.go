@@ -0,0 +1,144 @@
+package postgres
+
+import (
+	pb "Content-Service/genproto/content"
+	"Content-Service/help"
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+const (
+	MentionStory   = "story"
+	MentionComment = "comment"
+)
+
+// storeMentions replaces the mentions recorded for a story or story comment
+// with the @usernames in text that belong to existing users other than the
+// author, and returns the usernames that were resolved.
+func storeMentions(ctx context.Context, tx *sql.Tx, itemType, itemID, storyID, authorID, text string) ([]string, error) {
+	_, err := tx.ExecContext(ctx, `DELETE FROM mentions WHERE item_type = $1 AND item_id = $2`, itemType, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clear mentions: %v", err)
+	}
+
+	usernames := help.ExtractMentions(text)
+	if len(usernames) == 0 {
+		return nil, nil
+	}
+
+	query := `
+        INSERT INTO mentions (item_type, item_id, story_id, mentioned_user_id, author_id)
+        SELECT $1, $2, $3, u.id, $4
+        FROM users u
+        WHERE lower(u.username) = ANY($5) AND u.deleted_at = 0 AND u.id <> $4
+        RETURNING (SELECT username FROM users WHERE id = mentioned_user_id)
+    `
+	rows, err := tx.QueryContext(ctx, query, itemType, itemID, storyID, authorID, pq.Array(usernames))
+	if err != nil {
+		return nil, fmt.Errorf("failed to store mentions: %v", err)
+	}
+	defer rows.Close()
+
+	var resolved []string
+	for rows.Next() {
+		var username string
+		if err := rows.Scan(&username); err != nil {
+			return nil, err
+		}
+		resolved = append(resolved, username)
+	}
+
+	return resolved, rows.Err()
+}
+
+// getMentions returns the usernames mentioned by a story or story comment.
+func getMentions(ctx context.Context, q querier, itemType, itemID string) ([]string, error) {
+	query := `
+        SELECT u.username
+        FROM mentions m
+        JOIN users u ON m.mentioned_user_id = u.id
+        WHERE m.item_type = $1 AND m.item_id = $2
+        ORDER BY u.username
+    `
+	rows, err := q.QueryContext(ctx, query, itemType, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch mentions: %v", err)
+	}
+	defer rows.Close()
+
+	var usernames []string
+	for rows.Next() {
+		var username string
+		if err := rows.Scan(&username); err != nil {
+			return nil, err
+		}
+		usernames = append(usernames, username)
+	}
+
+	return usernames, rows.Err()
+}
+
+// addHashtags merges the #hashtags in text into the tags of a story.
+func addHashtags(ctx context.Context, tx execer, storyID, text string) error {
+	return addStoryTags(ctx, tx, storyID, help.ExtractHashtags(text))
+}
+
+// ListMentions lists the stories and story comments mentioning a user that
+// the viewer can see, newest first.
+func (c *ContentRepo) ListMentions(ctx context.Context, req *pb.ListMentionsReq) (*pb.ListMentionsRes, error) {
+	from := `
+        FROM mentions m
+        JOIN stories s ON m.story_id = s.id
+        LEFT JOIN comments c ON m.item_type = 'comment' AND m.item_id = c.id
+        JOIN users u ON m.author_id = u.id
+        WHERE m.mentioned_user_id = $1 AND s.deleted_at = 0 AND ` + listedStorySQL("s", viewerParam(2)) + `
+          AND (m.item_type = 'story' OR c.id IS NOT NULL)`
+
+	var total int64
+	err := c.DB.QueryRowContext(ctx, `SELECT COUNT(*) `+from, req.UserId, req.ViewerId).Scan(&total)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count mentions: %v", err)
+	}
+
+	query := `
+        SELECT m.item_type, m.item_id, s.id, s.title, u.id, u.username, u.full_name,
+               CASE WHEN m.item_type = 'comment' THEN c.content ELSE s.excerpt END, m.created_at
+        ` + from + `
+        ORDER BY m.created_at DESC
+        LIMIT $3 OFFSET $4
+    `
+	rows, err := c.DB.QueryContext(ctx, query, req.UserId, req.ViewerId, req.Limit, req.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch mentions: %v", err)
+	}
+	defer rows.Close()
+
+	var mentions []*pb.Mention
+	for rows.Next() {
+		var mention pb.Mention
+		var author pb.Author
+		err := rows.Scan(&mention.ItemType, &mention.ItemId, &mention.StoryId, &mention.StoryTitle,
+			&author.UserId, &author.Username, &author.FullName, &mention.Excerpt, &mention.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan mention row: %v", err)
+		}
+		mention.Author = &author
+		if mention.ItemType == MentionComment {
+			mention.Excerpt = help.Excerpt(mention.Excerpt, excerptLength)
+		}
+		mentions = append(mentions, &mention)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &pb.ListMentionsRes{
+		Mentions: mentions,
+		Total:    total,
+		Limit:    req.Limit,
+		Offset:   req.Offset,
+	}, nil
+}
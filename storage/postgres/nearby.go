@@ -0,0 +1,140 @@
+package postgres
+
+import (
+	pb "Content-Service/genproto/content"
+	"Content-Service/help"
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+const (
+	NearbyDestinations = "destinations"
+	NearbyStories      = "stories"
+)
+
+// haversineSQL computes the great-circle distance in kilometres between the
+// row's latitude/longitude columns and the point given by $1, $2.
+const haversineSQL = `
+    2 * 6371 * asin(least(1, sqrt(
+        power(sin(radians(latitude - $1) / 2), 2) +
+        cos(radians($1)) * cos(radians(latitude)) * power(sin(radians(longitude - $2) / 2), 2)
+    )))`
+
+func (c *ContentRepo) GetNearby(ctx context.Context, req *pb.GetNearbyReq) (*pb.GetNearbyRes, error) {
+	box := help.BoundingBoxAround(req.Latitude, req.Longitude, req.RadiusKm)
+	res := &pb.GetNearbyRes{}
+
+	if req.Kind == "" || req.Kind == NearbyDestinations {
+		destinations, err := c.getNearbyDestinations(ctx, req, box)
+		if err != nil {
+			return nil, err
+		}
+		res.Destinations = destinations
+	}
+
+	if req.Kind == "" || req.Kind == NearbyStories {
+		stories, err := c.getNearbyStories(ctx, req, box)
+		if err != nil {
+			return nil, err
+		}
+		res.Stories = stories
+	}
+
+	return res, nil
+}
+
+func (c *ContentRepo) getNearbyDestinations(ctx context.Context, req *pb.GetNearbyReq, box help.BoundingBox) ([]*pb.NearbyDestination, error) {
+	query := `
+        SELECT id, name, country, latitude, longitude, distance
+        FROM (
+            SELECT id, name, country, latitude, longitude, ` + haversineSQL + ` AS distance
+            FROM destinations
+            WHERE deleted_at = 0
+              AND latitude BETWEEN $3 AND $4
+              AND longitude BETWEEN $5 AND $6
+        ) d
+        WHERE distance <= $7
+        ORDER BY distance
+        LIMIT $8
+    `
+	rows, err := c.DB.QueryContext(ctx, query, req.Latitude, req.Longitude,
+		box.MinLat, box.MaxLat, box.MinLng, box.MaxLng, req.RadiusKm, req.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch nearby destinations: %v", err)
+	}
+	defer rows.Close()
+
+	var destinations []*pb.NearbyDestination
+	for rows.Next() {
+		var destination pb.NearbyDestination
+		if err := rows.Scan(
+			&destination.Id,
+			&destination.Name,
+			&destination.Country,
+			&destination.Latitude,
+			&destination.Longitude,
+			&destination.DistanceKm,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan nearby destination row: %v", err)
+		}
+		destinations = append(destinations, &destination)
+	}
+
+	return destinations, rows.Err()
+}
+
+func (c *ContentRepo) getNearbyStories(ctx context.Context, req *pb.GetNearbyReq, box help.BoundingBox) ([]*pb.NearbyStory, error) {
+	query := `
+        SELECT s.id, s.title, s.location, s.latitude, s.longitude, s.distance, u.id, u.username, u.full_name
+        FROM (
+            SELECT id, title, location, author_id, visibility, status, latitude, longitude, ` + haversineSQL + ` AS distance
+            FROM stories
+            WHERE deleted_at = 0
+              AND latitude BETWEEN $3 AND $4
+              AND longitude BETWEEN $5 AND $6
+        ) s
+        JOIN users u ON s.author_id = u.id
+        WHERE s.distance <= $7 AND ` + listedStorySQL("s", viewerParam(9)) + `
+        ORDER BY s.distance
+        LIMIT $8
+    `
+	rows, err := c.DB.QueryContext(ctx, query, req.Latitude, req.Longitude,
+		box.MinLat, box.MaxLat, box.MinLng, box.MaxLng, req.RadiusKm, req.Limit, req.ViewerId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch nearby stories: %v", err)
+	}
+	defer rows.Close()
+
+	var stories []*pb.NearbyStory
+	for rows.Next() {
+		var story pb.NearbyStory
+		var author pb.Author
+		var location sql.NullString
+		if err := rows.Scan(
+			&story.StoryId,
+			&story.Title,
+			&location,
+			&story.Latitude,
+			&story.Longitude,
+			&story.DistanceKm,
+			&author.UserId,
+			&author.Username,
+			&author.FullName,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan nearby story row: %v", err)
+		}
+		story.Location = location.String
+		story.Author = &author
+		stories = append(stories, &story)
+	}
+
+	return stories, rows.Err()
+}
+
+func nullFloatPtr(f sql.NullFloat64) *float64 {
+	if !f.Valid {
+		return nil
+	}
+	return &f.Float64
+}
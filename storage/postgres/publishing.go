@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	pb "Content-Service/genproto/content"
+	"context"
+	"fmt"
+)
+
+const (
+	StatusDraft     = "draft"
+	StatusScheduled = "scheduled"
+	StatusPublished = "published"
+)
+
+// listedStorySQL is listedSQL for stories, which are only listed once
+// published unless the viewer is their author.
+func listedStorySQL(alias, viewer string) string {
+	return fmt.Sprintf("(%[3]s AND (%[1]s.status = 'published' OR %[1]s.author_id = %[2]s))", alias, viewer, listedSQL(alias, viewer))
+}
+
+// PublishStory publishes a draft or scheduled story immediately, or
+// schedules it when publish_at is set. It returns sql.ErrNoRows when the
+// story does not exist or is already published.
+func (c *ContentRepo) PublishStory(ctx context.Context, req *pb.PublishStoryReq) (*pb.PublishStoryRes, error) {
+	query := `
+        UPDATE stories
+        SET status = CASE WHEN $2 = '' THEN 'published' ELSE 'scheduled' END,
+            publish_at = CASE WHEN $2 = '' THEN CURRENT_TIMESTAMP ELSE $2::TIMESTAMPTZ END,
+            published_at = CASE WHEN $2 = '' THEN CURRENT_TIMESTAMP END,
+            updated_at = CURRENT_TIMESTAMP
+        WHERE id = $1 AND deleted_at = 0 AND status <> 'published'
+        RETURNING id, status, coalesce(publish_at::TEXT, ''), coalesce(published_at::TEXT, '')
+    `
+	var res pb.PublishStoryRes
+	err := c.DB.QueryRowContext(ctx, query, req.StoryId, req.PublishAt).Scan(&res.StoryId, &res.Status, &res.PublishAt, &res.PublishedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// PublishDueStories publishes up to limit scheduled stories whose publish
+// time has passed and returns their ids. Rows claimed by a concurrent call
+// are skipped, so replicas running the scheduler side by side never
+// publish the same story twice.
+func (c *ContentRepo) PublishDueStories(ctx context.Context, limit int) ([]string, error) {
+	query := `
+        UPDATE stories
+        SET status = 'published', published_at = publish_at, updated_at = CURRENT_TIMESTAMP
+        WHERE id IN (
+            SELECT id
+            FROM stories
+            WHERE status = 'scheduled' AND publish_at <= CURRENT_TIMESTAMP AND deleted_at = 0
+            ORDER BY publish_at
+            LIMIT $1
+            FOR UPDATE SKIP LOCKED
+        )
+        RETURNING id
+    `
+	rows, err := c.DB.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to publish scheduled stories: %v", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan published story id: %v", err)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
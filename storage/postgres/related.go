@@ -0,0 +1,123 @@
+package postgres
+
+import (
+	pb "Content-Service/genproto/content"
+	"context"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// Weights of the signals that relate two stories. Text similarity is the
+// pg_trgm similarity of title and excerpt, between 0 and 1.
+const (
+	relatedTagWeight         = 3
+	relatedDestinationWeight = 2
+	relatedLocationWeight    = 1.5
+	relatedAuthorWeight      = 1
+	relatedTextWeight        = 4
+
+	// minRelatedScore keeps out stories whose only link is weak text
+	// similarity.
+	minRelatedScore = 1
+)
+
+// relatedTextSQL is the text compared for similarity. It matches the
+// expression of the trigram index on stories.
+func relatedTextSQL(alias string) string {
+	return fmt.Sprintf("(%[1]s.title || ' ' || %[1]s.excerpt)", alias)
+}
+
+// RelatedStoryIDs ranks up to limit other stories by how related they are to
+// a story: shared tags, the same destination or location, the same author
+// and similar text. Only live, published, public stories are considered, so
+// the result is the same for every viewer and can be cached.
+func (c *ContentRepo) RelatedStoryIDs(ctx context.Context, storyID string, limit int64) ([]string, error) {
+	query := fmt.Sprintf(`
+        WITH target AS (
+            SELECT s.id, s.author_id, s.destination_id, coalesce(lower(trim(s.location)), '') AS location, %[1]s AS text
+            FROM stories s
+            WHERE s.id = $1
+        ),
+        scored AS (
+            SELECT s.id, s.created_at,
+                   (SELECT COUNT(*) FROM story_tags st JOIN story_tags tt ON tt.tag = st.tag AND tt.story_id = t.id
+                    WHERE st.story_id = s.id) * %[3]g
+                   + CASE
+                         WHEN s.destination_id = t.destination_id THEN %[4]g
+                         WHEN t.location <> '' AND lower(trim(s.location)) = t.location THEN %[5]g
+                         ELSE 0
+                     END
+                   + CASE WHEN s.author_id = t.author_id THEN %[6]g ELSE 0 END
+                   + similarity(%[2]s, t.text) * %[7]g AS score
+            FROM stories s, target t
+            WHERE s.id <> t.id AND s.deleted_at = 0 AND s.status = 'published' AND s.visibility = 'public'
+              AND (s.author_id = t.author_id OR s.destination_id = t.destination_id
+                   OR (t.location <> '' AND lower(trim(s.location)) = t.location)
+                   OR %[2]s %% t.text
+                   OR EXISTS (SELECT 1 FROM story_tags st JOIN story_tags tt ON tt.tag = st.tag AND tt.story_id = t.id
+                              WHERE st.story_id = s.id))
+        )
+        SELECT id
+        FROM scored
+        WHERE score >= %[8]g
+        ORDER BY score DESC, created_at DESC
+        LIMIT $2
+    `, relatedTextSQL("s"), relatedTextSQL("s"), float64(relatedTagWeight), float64(relatedDestinationWeight),
+		float64(relatedLocationWeight), float64(relatedAuthorWeight), float64(relatedTextWeight), float64(minRelatedScore))
+
+	rows, err := c.DB.QueryContext(ctx, query, storyID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rank related stories: %v", err)
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan related story row: %v", err)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// GetPublicStories returns up to limit of the given stories in the given
+// order, leaving out those that have since been deleted, unpublished or
+// made non-public.
+func (c *ContentRepo) GetPublicStories(ctx context.Context, ids []string, limit int64) ([]*pb.Stories, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	query := `
+        SELECT ` + storySummaryColumns + `
+        FROM stories s
+        JOIN users u ON s.author_id = u.id
+        WHERE s.id = ANY($1::UUID[]) AND s.deleted_at = 0 AND s.status = 'published' AND s.visibility = 'public'
+        ORDER BY array_position($1::UUID[], s.id)
+        LIMIT $2
+    `
+	rows, err := c.DB.QueryContext(ctx, query, pq.Array(ids), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch related stories: %v", err)
+	}
+	defer rows.Close()
+
+	var stories []*pb.Stories
+	for rows.Next() {
+		story, err := scanStorySummary(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan related story row: %v", err)
+		}
+		stories = append(stories, story)
+	}
+
+	return stories, rows.Err()
+}
+
+// StoryTags returns the tags of a story in alphabetical order.
+func (c *ContentRepo) StoryTags(ctx context.Context, storyID string) ([]string, error) {
+	return getStoryTags(ctx, c.DB, storyID)
+}
@@ -0,0 +1,52 @@
+package postgres
+
+import (
+	pb "Content-Service/genproto/content"
+	"context"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestRelatedStoryIDs(t *testing.T) {
+	c := newTestRepo(t)
+	ctx := context.Background()
+	author, other := createTestUser(t, c), createTestUser(t, c)
+	suffix := strconv.FormatInt(time.Now().UnixNano(), 36)
+	tags := []string{"silkroad" + suffix, "samarkand" + suffix}
+
+	target := createTestStory(t, c, &pb.CreateStoriesRequest{UserId: author, Title: "Plov in Samarkand", Tags: tags})
+	sharedTags := createTestStory(t, c, &pb.CreateStoriesRequest{UserId: other, Title: "Registan at night", Tags: tags})
+	sameAuthor := createTestStory(t, c, &pb.CreateStoriesRequest{UserId: author, Title: "Packing list"})
+	private := createTestStory(t, c, &pb.CreateStoriesRequest{UserId: other, Title: "Private notes", Tags: tags, Visibility: VisibilityPrivate})
+	draft := createTestStory(t, c, &pb.CreateStoriesRequest{UserId: other, Title: "Draft", Tags: tags, Status: StatusDraft})
+	deleted := createTestStory(t, c, &pb.CreateStoriesRequest{UserId: other, Title: "Deleted", Tags: tags})
+	if err := c.DeleteStory(ctx, &pb.StoryId{Id: deleted.Id}); err != nil {
+		t.Fatal(err)
+	}
+
+	ids, err := c.RelatedStoryIDs(ctx, target.Id, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) == 0 || ids[0] != sharedTags.Id {
+		t.Errorf("related stories = %v, want the story sharing tags %s first", ids, sharedTags.Id)
+	}
+	if !slices.Contains(ids, sameAuthor.Id) {
+		t.Errorf("related stories = %v, want the author's other story %s", ids, sameAuthor.Id)
+	}
+	for name, id := range map[string]string{"target": target.Id, "private": private.Id, "draft": draft.Id, "deleted": deleted.Id} {
+		if slices.Contains(ids, id) {
+			t.Errorf("related stories include the %s story", name)
+		}
+	}
+
+	stories, err := c.GetPublicStories(ctx, []string{sameAuthor.Id, private.Id, sharedTags.Id}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stories) != 2 || stories[0].StoryId != sameAuthor.Id || stories[1].StoryId != sharedTags.Id {
+		t.Errorf("GetPublicStories kept %d stories out of order or with the private one", len(stories))
+	}
+}
@@ -0,0 +1,101 @@
+package postgres
+
+import (
+	pb "Content-Service/genproto/content"
+	"context"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// snapshotStory copies the current title, content, content format, location
+// and tags of a story into story_revisions before an edit by editorID. It
+// copies nothing when the story is missing or its version differs from a
+// non-zero expectedVersion, leaving the following update to report the
+// problem.
+func snapshotStory(ctx context.Context, tx execer, storyID string, expectedVersion int64, editorID string) error {
+	query := `
+        INSERT INTO story_revisions (story_id, version, title, content, location, tags, edited_by, content_format)
+        SELECT s.id, s.version, s.title, s.content, s.location,
+               ARRAY(SELECT tag FROM story_tags WHERE story_id = s.id ORDER BY tag),
+               NULLIF($3, '')::UUID, s.content_format
+        FROM stories s
+        WHERE s.id = $1 AND s.deleted_at = 0 AND ($2 = 0 OR s.version = $2)
+        FOR UPDATE OF s
+    `
+	_, err := tx.ExecContext(ctx, query, storyID, expectedVersion, editorID)
+	if err != nil {
+		return fmt.Errorf("failed to snapshot story: %v", err)
+	}
+	return nil
+}
+
+const storyRevisionColumns = `
+        id, story_id, version, title, content, coalesce(location, ''), tags, coalesce(edited_by::TEXT, ''), created_at,
+        content_format`
+
+func scanStoryRevision(row interface{ Scan(...interface{}) error }) (*pb.StoryRevision, error) {
+	var revision pb.StoryRevision
+	err := row.Scan(
+		&revision.Id,
+		&revision.StoryId,
+		&revision.Version,
+		&revision.Title,
+		&revision.Content,
+		&revision.Location,
+		pq.Array(&revision.Tags),
+		&revision.EditedBy,
+		&revision.CreatedAt,
+		&revision.ContentFormat,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
+
+func (c *ContentRepo) ListStoryRevisions(ctx context.Context, req *pb.ListStoryRevisionsReq) (*pb.ListStoryRevisionsRes, error) {
+	var total int64
+	err := c.DB.QueryRowContext(ctx, `SELECT COUNT(*) FROM story_revisions WHERE story_id = $1`, req.StoryId).Scan(&total)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count story revisions: %v", err)
+	}
+
+	query := `
+        SELECT ` + storyRevisionColumns + `
+        FROM story_revisions
+        WHERE story_id = $1
+        ORDER BY version DESC, created_at DESC
+        LIMIT $2 OFFSET $3
+    `
+	rows, err := c.DB.QueryContext(ctx, query, req.StoryId, req.Limit, req.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch story revisions: %v", err)
+	}
+	defer rows.Close()
+
+	var revisions []*pb.StoryRevision
+	for rows.Next() {
+		revision, err := scanStoryRevision(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan story revision row: %v", err)
+		}
+		revisions = append(revisions, revision)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &pb.ListStoryRevisionsRes{
+		Revisions: revisions,
+		Total:     total,
+		Limit:     req.Limit,
+		Offset:    req.Offset,
+	}, nil
+}
+
+// GetStoryRevision returns a revision of a story, or sql.ErrNoRows.
+func (c *ContentRepo) GetStoryRevision(ctx context.Context, storyID, revisionID string) (*pb.StoryRevision, error) {
+	query := `SELECT ` + storyRevisionColumns + ` FROM story_revisions WHERE id = $1 AND story_id = $2`
+	return scanStoryRevision(c.DB.QueryRowContext(ctx, query, revisionID, storyID))
+}
@@ -0,0 +1,292 @@
+package postgres
+
+import (
+	pb "Content-Service/genproto/content"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+var (
+	// ErrStoryInSeries is returned when a story already belongs to a series;
+	// a story can be part of only one.
+	ErrStoryInSeries = errors.New("story already belongs to a series")
+
+	// ErrSeriesOrder is returned when a new order does not list every story
+	// of the series exactly once.
+	ErrSeriesOrder = errors.New("story_ids must list every story of the series exactly once")
+)
+
+// CreateSeries creates an empty series.
+func (c *ContentRepo) CreateSeries(ctx context.Context, req *pb.CreateSeriesReq) (*pb.Series, error) {
+	query := `
+        INSERT INTO series (author_id, title, description)
+        VALUES ($1, $2, $3)
+        RETURNING id, author_id, title, description, created_at, updated_at
+    `
+	var series pb.Series
+	err := c.DB.QueryRowContext(ctx, query, req.UserId, req.Title, req.Description).Scan(
+		&series.Id, &series.AuthorId, &series.Title, &series.Description, &series.CreatedAt, &series.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create series: %v", err)
+	}
+
+	return &series, nil
+}
+
+// SeriesAuthor returns the author of a series, or sql.ErrNoRows.
+func (c *ContentRepo) SeriesAuthor(ctx context.Context, id string) (string, error) {
+	var authorID string
+	err := c.DB.QueryRowContext(ctx, `SELECT author_id FROM series WHERE id = $1`, id).Scan(&authorID)
+	return authorID, err
+}
+
+// AddStoryToSeries inserts a story at a 1-based position among the live
+// stories of a series, or appends it when position is not within them.
+// Stories after it move down one place.
+func (c *ContentRepo) AddStoryToSeries(ctx context.Context, req *pb.AddStoryToSeriesReq) (*pb.Series, error) {
+	tx, err := c.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var count int64
+	err = lockSeries(ctx, tx, req.SeriesId)
+	if err == nil {
+		err = tx.QueryRowContext(ctx, `
+            SELECT COUNT(*)
+            FROM series_stories ss
+            JOIN stories s ON ss.story_id = s.id
+            WHERE ss.series_id = $1 AND s.deleted_at = 0
+        `, req.SeriesId).Scan(&count)
+	}
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	position := req.Position
+	if position <= 0 || position > count {
+		position = count + 1
+	}
+
+	// Renumber the series densely, leaving a gap at position; purged
+	// stories may have left holes. Positions count live stories only, so
+	// stories in the trash move after them, as ReorderSeries keeps them.
+	shift := `
+        UPDATE series_stories ss
+        SET position = r.n + CASE WHEN r.n >= $2 THEN 1 ELSE 0 END
+        FROM (
+            SELECT m.story_id, ROW_NUMBER() OVER (ORDER BY s.deleted_at > 0, m.position) AS n
+            FROM series_stories m
+            JOIN stories s ON m.story_id = s.id
+            WHERE m.series_id = $1
+        ) r
+        WHERE ss.story_id = r.story_id
+    `
+	if _, err := tx.ExecContext(ctx, shift, req.SeriesId, position); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to shift series stories: %v", err)
+	}
+
+	insert := `
+        INSERT INTO series_stories (story_id, series_id, position)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (story_id) DO NOTHING
+    `
+	result, err := tx.ExecContext(ctx, insert, req.StoryId, req.SeriesId, position)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to add story to series: %v", err)
+	}
+	if n, err := result.RowsAffected(); err != nil || n == 0 {
+		tx.Rollback()
+		if err != nil {
+			return nil, err
+		}
+		return nil, ErrStoryInSeries
+	}
+	_, err = tx.ExecContext(ctx, `UPDATE series SET updated_at = CURRENT_TIMESTAMP WHERE id = $1`, req.SeriesId)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to update series: %v", err)
+	}
+
+	series, err := getSeries(ctx, tx, req.SeriesId)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return series, nil
+}
+
+// ReorderSeries puts the stories of a series in the given order. It returns
+// ErrSeriesOrder unless storyIDs lists every story of the series that is not
+// in the trash exactly once.
+func (c *ContentRepo) ReorderSeries(ctx context.Context, req *pb.ReorderSeriesReq) (*pb.Series, error) {
+	tx, err := c.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := lockSeries(ctx, tx, req.SeriesId); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// The member count and the match count agree only when storyIDs is a
+	// permutation of the live members.
+	check := `
+        SELECT COUNT(*), COUNT(*) FILTER (WHERE s.id = ANY($2::UUID[]))
+        FROM series_stories ss
+        JOIN stories s ON ss.story_id = s.id
+        WHERE ss.series_id = $1 AND s.deleted_at = 0
+    `
+	var members, matched int
+	if err := tx.QueryRowContext(ctx, check, req.SeriesId, pq.Array(req.StoryIds)).Scan(&members, &matched); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to check series order: %v", err)
+	}
+	if members != len(req.StoryIds) || matched != members || hasDuplicates(req.StoryIds) {
+		tx.Rollback()
+		return nil, ErrSeriesOrder
+	}
+
+	// Stories in the trash keep their relative order after the others, so
+	// they come back at the end of the series when restored.
+	query := `
+        UPDATE series_stories ss
+        SET position = r.n
+        FROM (
+            SELECT m.story_id, ROW_NUMBER() OVER (ORDER BY o.n NULLS LAST, m.position) AS n
+            FROM series_stories m
+            LEFT JOIN unnest($2::UUID[]) WITH ORDINALITY AS o(story_id, n) ON o.story_id = m.story_id
+            WHERE m.series_id = $1
+        ) r
+        WHERE ss.story_id = r.story_id
+    `
+	if _, err := tx.ExecContext(ctx, query, req.SeriesId, pq.Array(req.StoryIds)); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to reorder series: %v", err)
+	}
+	_, err = tx.ExecContext(ctx, `UPDATE series SET updated_at = CURRENT_TIMESTAMP WHERE id = $1`, req.SeriesId)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to update series: %v", err)
+	}
+
+	series, err := getSeries(ctx, tx, req.SeriesId)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return series, nil
+}
+
+// lockSeries locks a series row until the end of tx so concurrent changes
+// to its order are serialized. It returns sql.ErrNoRows for an unknown
+// series.
+func lockSeries(ctx context.Context, tx *sql.Tx, id string) error {
+	var locked string
+	return tx.QueryRowContext(ctx, `SELECT id FROM series WHERE id = $1 FOR UPDATE`, id).Scan(&locked)
+}
+
+// getSeries returns a series with all its live stories in order, as seen by
+// its author.
+func getSeries(ctx context.Context, tx *sql.Tx, id string) (*pb.Series, error) {
+	var series pb.Series
+	query := `SELECT id, author_id, title, description, created_at, updated_at FROM series WHERE id = $1`
+	err := tx.QueryRowContext(ctx, query, id).Scan(
+		&series.Id, &series.AuthorId, &series.Title, &series.Description, &series.CreatedAt, &series.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	query = `
+        SELECT s.id, s.title, ROW_NUMBER() OVER (ORDER BY ss.position)
+        FROM series_stories ss
+        JOIN stories s ON ss.story_id = s.id
+        WHERE ss.series_id = $1 AND s.deleted_at = 0
+        ORDER BY ss.position
+    `
+	rows, err := tx.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch series stories: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var story pb.SeriesStory
+		if err := rows.Scan(&story.StoryId, &story.Title, &story.Position); err != nil {
+			return nil, fmt.Errorf("failed to scan series story row: %v", err)
+		}
+		series.Stories = append(series.Stories, &story)
+	}
+
+	return &series, rows.Err()
+}
+
+// GetStorySeries returns the series a story belongs to with its place in it
+// and the previous and next stories, counting only the stories listed for
+// the viewer. It returns nil when the story is not part of a series.
+func (c *ContentRepo) GetStorySeries(ctx context.Context, storyID, viewerID string) (*pb.StorySeries, error) {
+	query := `
+        WITH members AS (
+            SELECT ss.series_id, s.id, s.title, ROW_NUMBER() OVER (ORDER BY ss.position) AS n
+            FROM series_stories ss
+            JOIN stories s ON ss.story_id = s.id
+            WHERE ss.series_id = (SELECT series_id FROM series_stories WHERE story_id = $1)
+              AND s.deleted_at = 0 AND (s.id = $1 OR ` + listedStorySQL("s", viewerParam(2)) + `)
+        )
+        SELECT m.series_id, se.title, m.n, (SELECT COUNT(*) FROM members),
+               coalesce(p.id::TEXT, ''), coalesce(p.title, ''), coalesce(x.id::TEXT, ''), coalesce(x.title, '')
+        FROM members m
+        JOIN series se ON se.id = m.series_id
+        LEFT JOIN members p ON p.n = m.n - 1
+        LEFT JOIN members x ON x.n = m.n + 1
+        WHERE m.id = $1
+    `
+	var series pb.StorySeries
+	var previousID, previousTitle, nextID, nextTitle string
+	err := c.DB.QueryRowContext(ctx, query, storyID, viewerID).Scan(
+		&series.SeriesId, &series.Title, &series.Position, &series.Total,
+		&previousID, &previousTitle, &nextID, &nextTitle)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch story series: %v", err)
+	}
+
+	if previousID != "" {
+		series.Previous = &pb.SeriesStory{StoryId: previousID, Title: previousTitle, Position: series.Position - 1}
+	}
+	if nextID != "" {
+		series.Next = &pb.SeriesStory{StoryId: nextID, Title: nextTitle, Position: series.Position + 1}
+	}
+
+	return &series, nil
+}
+
+func hasDuplicates(ids []string) bool {
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			return true
+		}
+		seen[id] = true
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+package postgres
+
+import (
+	pb "Content-Service/genproto/content"
+	"context"
+	"testing"
+)
+
+func TestAddStoryToSeriesIgnoresTrashedStories(t *testing.T) {
+	c := newTestRepo(t)
+	ctx := context.Background()
+	author := createTestUser(t, c)
+	series, err := c.CreateSeries(ctx, &pb.CreateSeriesReq{UserId: author, Title: "Test series"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []string
+	for _, title := range []string{"A", "B", "D"} {
+		story := createTestStory(t, c, &pb.CreateStoriesRequest{UserId: author, Title: title})
+		_, err := c.AddStoryToSeries(ctx, &pb.AddStoryToSeriesReq{UserId: author, SeriesId: series.Id, StoryId: story.Id})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, story.Id)
+	}
+	if err := c.DeleteStory(ctx, &pb.StoryId{Id: ids[1]}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Position 3 is past the two live stories, so C is appended.
+	story := createTestStory(t, c, &pb.CreateStoriesRequest{UserId: author, Title: "C"})
+	got, err := c.AddStoryToSeries(ctx, &pb.AddStoryToSeriesReq{
+		UserId: author, SeriesId: series.Id, StoryId: story.Id, Position: 3,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.UpdatedAt == series.UpdatedAt {
+		t.Errorf("updated_at = %q, want it moved on from creation", got.UpdatedAt)
+	}
+
+	want := []string{ids[0], ids[2], story.Id}
+	if len(got.Stories) != len(want) {
+		t.Fatalf("series has %d stories, want %d", len(got.Stories), len(want))
+	}
+	for i, s := range got.Stories {
+		if s.StoryId != want[i] || s.Position != int64(i+1) {
+			t.Errorf("story %d = %s at %d, want %s at %d", i, s.StoryId, s.Position, want[i], i+1)
+		}
+	}
+}
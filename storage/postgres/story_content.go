@@ -0,0 +1,151 @@
+package postgres
+
+import (
+	pb "Content-Service/genproto/content"
+	"Content-Service/help"
+	"Content-Service/markdown"
+	"context"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+const (
+	ContentFormatPlain    = "plain"
+	ContentFormatMarkdown = "markdown"
+)
+
+// excerptLength is the length in runes of the story excerpts shown in
+// listings.
+const excerptLength = 200
+
+// ValidContentFormat reports whether f is one of the known content formats.
+func ValidContentFormat(f string) bool {
+	return f == ContentFormatPlain || f == ContentFormatMarkdown
+}
+
+// renderedContent holds what is derived from the source of a story and
+// stored next to it.
+type renderedContent struct {
+	HTML           string
+	Excerpt        string
+	WordCount      int
+	ReadingMinutes int
+}
+
+// renderContent derives the sanitized HTML, excerpt and reading statistics
+// of story content written in format.
+func renderContent(format, content string) renderedContent {
+	text := content
+	rendered := renderedContent{}
+	if format == ContentFormatMarkdown {
+		rendered.HTML = markdown.HTML(content)
+		text = markdown.Text(content)
+	} else {
+		rendered.HTML = markdown.PlainHTML(content)
+	}
+	rendered.Excerpt = help.Excerpt(text, excerptLength)
+	rendered.WordCount = help.WordCount(text)
+	rendered.ReadingMinutes = help.ReadingMinutes(rendered.WordCount)
+	return rendered
+}
+
+// storeRenderedContent renders the content of a story and stores the
+// results next to its source.
+func storeRenderedContent(ctx context.Context, tx execer, storyID, format, content string) (renderedContent, error) {
+	rendered := renderContent(format, content)
+	query := `
+        UPDATE stories
+        SET content_html = $2, excerpt = $3, word_count = $4, reading_time_minutes = $5
+        WHERE id = $1
+    `
+	_, err := tx.ExecContext(ctx, query, storyID, rendered.HTML, rendered.Excerpt, rendered.WordCount, rendered.ReadingMinutes)
+	if err != nil {
+		return renderedContent{}, fmt.Errorf("failed to store rendered story content: %v", err)
+	}
+	return rendered, nil
+}
+
+// storySummaryColumns selects the fields of a story listing entry from
+// stories s joined with its author u.
+var storySummaryColumns = `
+        s.id, s.title, s.location, s.likes_count, s.comments_count, s.visibility, s.status,
+        s.excerpt, s.word_count, s.reading_time_minutes,
+        ARRAY(SELECT tag FROM story_tags WHERE story_id = s.id ORDER BY tag), s.created_at,
+        ` + savedCountSQL("'story'", "s.id") + `, s.views_count, u.id, u.username, u.full_name`
+
+func scanStorySummary(row interface{ Scan(...interface{}) error }) (*pb.Stories, error) {
+	var story pb.Stories
+	var author pb.Author
+	err := row.Scan(
+		&story.StoryId,
+		&story.Title,
+		&story.Location,
+		&story.LikesCount,
+		&story.CommentsCount,
+		&story.Visibility,
+		&story.Status,
+		&story.Excerpt,
+		&story.WordCount,
+		&story.ReadingTimeMinutes,
+		pq.Array(&story.Tags),
+		&story.CreatedAt,
+		&story.SavedCount,
+		&story.ViewsCount,
+		&author.UserId,
+		&author.Username,
+		&author.FullName,
+	)
+	if err != nil {
+		return nil, err
+	}
+	story.Author = &author
+	return &story, nil
+}
+
+// BackfillStoryContent renders the content of every story again, in
+// batches of batchSize, and reports how many stories were updated. It fills
+// in the HTML, excerpt and reading statistics of stories written before
+// they were stored, and refreshes them after the renderer changes.
+func (c *ContentRepo) BackfillStoryContent(ctx context.Context, batchSize int) (int64, error) {
+	query := `
+        SELECT id, content_format, coalesce(content, '')
+        FROM stories
+        WHERE id > $1
+        ORDER BY id
+        LIMIT $2
+    `
+	var total int64
+	lastID := "00000000-0000-0000-0000-000000000000"
+	for {
+		type source struct{ id, format, content string }
+		rows, err := c.DB.QueryContext(ctx, query, lastID, batchSize)
+		if err != nil {
+			return total, fmt.Errorf("failed to fetch stories: %v", err)
+		}
+		var batch []source
+		for rows.Next() {
+			var s source
+			if err := rows.Scan(&s.id, &s.format, &s.content); err != nil {
+				rows.Close()
+				return total, err
+			}
+			batch = append(batch, s)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return total, err
+		}
+		if len(batch) == 0 {
+			return total, nil
+		}
+
+		for _, s := range batch {
+			if _, err := storeRenderedContent(ctx, c.DB, s.id, s.format, s.content); err != nil {
+				return total, err
+			}
+			total++
+		}
+		lastID = batch[len(batch)-1].id
+	}
+}
@@ -0,0 +1,82 @@
+package postgres
+
+import (
+	pb "Content-Service/genproto/content"
+	"Content-Service/help"
+	"context"
+	"fmt"
+)
+
+// Field mask paths accepted by UpdateStory.
+const (
+	StoryPathTitle         = "title"
+	StoryPathContent       = "content"
+	StoryPathContentFormat = "content_format"
+	StoryPathLocation      = "location"
+	StoryPathLatitude      = "latitude"
+	StoryPathLongitude     = "longitude"
+	StoryPathDestinationID = "destination_id"
+	StoryPathVisibility    = "visibility"
+	StoryPathTags          = "tags"
+	StoryPathMediaIDs      = "media_ids"
+)
+
+// StoryPaths lists every field mask path UpdateStory understands.
+var StoryPaths = []string{
+	StoryPathTitle, StoryPathContent, StoryPathContentFormat, StoryPathLocation, StoryPathLatitude, StoryPathLongitude,
+	StoryPathDestinationID, StoryPathVisibility, StoryPathTags, StoryPathMediaIDs,
+}
+
+// PopulatedStoryPaths returns the paths of the fields set on a request that
+// carries no update mask, so that unset fields are left untouched instead of
+// being wiped.
+func PopulatedStoryPaths(req *pb.UpdateStoriesReq) []string {
+	var paths []string
+	if req.Title != "" {
+		paths = append(paths, StoryPathTitle)
+	}
+	if req.Content != "" {
+		paths = append(paths, StoryPathContent)
+	}
+	if req.ContentFormat != "" {
+		paths = append(paths, StoryPathContentFormat)
+	}
+	if req.Location != "" {
+		paths = append(paths, StoryPathLocation)
+	}
+	if req.Latitude != nil {
+		paths = append(paths, StoryPathLatitude)
+	}
+	if req.Longitude != nil {
+		paths = append(paths, StoryPathLongitude)
+	}
+	if req.DestinationId != "" {
+		paths = append(paths, StoryPathDestinationID)
+	}
+	if req.Visibility != "" {
+		paths = append(paths, StoryPathVisibility)
+	}
+	if len(req.Tags) > 0 {
+		paths = append(paths, StoryPathTags)
+	}
+	if len(req.MediaIds) > 0 {
+		paths = append(paths, StoryPathMediaIDs)
+	}
+	return paths
+}
+
+// addStoryTags attaches tags to a story in normalized form, resolving
+// aliases and skipping blanks and tags it already has.
+func addStoryTags(ctx context.Context, tx execer, storyID string, tags []string) error {
+	query := `
+        INSERT INTO story_tags (story_id, tag)
+        VALUES ($1, ` + canonicalTagSQL("$2::TEXT") + `)
+        ON CONFLICT DO NOTHING
+    `
+	for _, tag := range help.NormalizeTags(tags) {
+		if _, err := tx.ExecContext(ctx, query, storyID, tag); err != nil {
+			return fmt.Errorf("failed to add story tag: %v", err)
+		}
+	}
+	return nil
+}
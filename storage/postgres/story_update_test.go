@@ -0,0 +1,37 @@
+package postgres
+
+import (
+	pb "Content-Service/genproto/content"
+	"context"
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestUpdateStoryReplacingTagsKeepsHashtags(t *testing.T) {
+	c := newTestRepo(t)
+	ctx := context.Background()
+	author := createTestUser(t, c)
+	story := createTestStory(t, c, &pb.CreateStoriesRequest{
+		UserId: author, Content: "A walk in #Paris", Tags: []string{"food"},
+	})
+
+	_, err := c.UpdateStory(ctx, &pb.UpdateStoriesReq{
+		Id:         story.Id,
+		UserId:     author,
+		Tags:       []string{"museums"},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{StoryPathTags}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tags, err := getStoryTags(ctx, c.DB, story.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"museums", "paris"}; !reflect.DeepEqual(tags, want) {
+		t.Errorf("tags = %q, want %q", tags, want)
+	}
+}
@@ -0,0 +1,307 @@
+package postgres
+
+import (
+	pb "Content-Service/genproto/content"
+	"Content-Service/help"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// ErrInvalidTagAlias is returned for an alias that is blank, or the same
+// as its tag, once both are normalized.
+var ErrInvalidTagAlias = errors.New("invalid tag alias")
+
+// minTrendingCount is the number of recent stories a tag needs before it
+// can trend, so that a single new story does not top the list.
+const minTrendingCount = 2
+
+// taggedStorySQL joins story_tags t to the live, published, public stories
+// s that tag counts are based on.
+const taggedStorySQL = `
+        FROM story_tags t
+        JOIN stories s ON t.story_id = s.id
+        WHERE s.deleted_at = 0 AND s.status = 'published' AND s.visibility = 'public'`
+
+// canonicalTagSQL returns an expression resolving the normalized tag expr
+// through tag_aliases.
+func canonicalTagSQL(expr string) string {
+	return fmt.Sprintf("coalesce((SELECT a.tag FROM tag_aliases a WHERE a.alias = %[1]s), %[1]s)", expr)
+}
+
+// likePrefix escapes the LIKE wildcards in prefix and appends one.
+func likePrefix(prefix string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(prefix) + "%"
+}
+
+// getStoryTags returns the tags of a story in alphabetical order.
+func getStoryTags(ctx context.Context, q querier, storyID string) ([]string, error) {
+	rows, err := q.QueryContext(ctx, `SELECT tag FROM story_tags WHERE story_id = $1 ORDER BY tag`, storyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch story tags: %v", err)
+	}
+	defer rows.Close()
+
+	var tags []string
+	for rows.Next() {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+
+	return tags, rows.Err()
+}
+
+// removeStoryTags detaches tags from a story, matching them the way
+// addStoryTags stores them.
+func removeStoryTags(ctx context.Context, tx execer, storyID string, tags []string) error {
+	tags = help.NormalizeTags(tags)
+	if len(tags) == 0 {
+		return nil
+	}
+	query := `
+        DELETE FROM story_tags
+        WHERE story_id = $1 AND tag IN (SELECT ` + canonicalTagSQL("r.tag") + ` FROM unnest($2::TEXT[]) AS r(tag))
+    `
+	if _, err := tx.ExecContext(ctx, query, storyID, pq.Array(tags)); err != nil {
+		return fmt.Errorf("failed to remove story tags: %v", err)
+	}
+	return nil
+}
+
+// ListTags lists the tags of public stories with the number of stories
+// using each, most used first. A prefix restricts the list to tags, or
+// tags with an alias, starting with it.
+func (c *ContentRepo) ListTags(ctx context.Context, req *pb.ListTagsReq) (*pb.ListTagsRes, error) {
+	filter := `
+          AND ($1 = '' OR t.tag LIKE $2 OR t.tag IN (SELECT a.tag FROM tag_aliases a WHERE a.alias LIKE $2))`
+	prefix := help.NormalizeTag(req.Prefix)
+
+	var total int64
+	err := c.DB.QueryRowContext(ctx, `SELECT COUNT(DISTINCT t.tag) `+taggedStorySQL+filter, prefix, likePrefix(prefix)).Scan(&total)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count tags: %v", err)
+	}
+
+	query := `
+        SELECT t.tag, COUNT(*) ` + taggedStorySQL + filter + `
+        GROUP BY t.tag
+        ORDER BY COUNT(*) DESC, t.tag
+        LIMIT $3 OFFSET $4
+    `
+	rows, err := c.DB.QueryContext(ctx, query, prefix, likePrefix(prefix), req.Limit, req.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch tags: %v", err)
+	}
+	defer rows.Close()
+
+	var tags []*pb.TagCount
+	for rows.Next() {
+		var tag pb.TagCount
+		if err := rows.Scan(&tag.Tag, &tag.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan tag row: %v", err)
+		}
+		tags = append(tags, &tag)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &pb.ListTagsRes{
+		Tags:   tags,
+		Total:  total,
+		Limit:  req.Limit,
+		Offset: req.Offset,
+	}, nil
+}
+
+// GetTrendingTags ranks tags by how much more often they were used on
+// stories published in the last windowHours than their rate over the
+// baselineDays before the window predicts. Both counts are smoothed by one
+// so new tags do not score infinitely.
+func (c *ContentRepo) GetTrendingTags(ctx context.Context, windowHours, baselineDays, limit int64) ([]*pb.TrendingTag, error) {
+	query := `
+        WITH counts AS (
+            SELECT t.tag,
+                   COUNT(*) FILTER (WHERE s.published_at >= CURRENT_TIMESTAMP - make_interval(hours => $1::INT)) AS recent,
+                   COUNT(*) FILTER (WHERE s.published_at < CURRENT_TIMESTAMP - make_interval(hours => $1::INT)) AS baseline
+            ` + taggedStorySQL + `
+              AND s.published_at >= CURRENT_TIMESTAMP - make_interval(hours => $1::INT) - make_interval(days => $2::INT)
+            GROUP BY t.tag
+        )
+        SELECT tag, recent, baseline,
+               (recent + 1)::FLOAT / (baseline * $1::FLOAT / ($2::FLOAT * 24) + 1) AS growth
+        FROM counts
+        WHERE recent >= $3
+        ORDER BY growth DESC, recent DESC, tag
+        LIMIT $4
+    `
+	rows, err := c.DB.QueryContext(ctx, query, windowHours, baselineDays, minTrendingCount, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch trending tags: %v", err)
+	}
+	defer rows.Close()
+
+	var tags []*pb.TrendingTag
+	for rows.Next() {
+		var tag pb.TrendingTag
+		if err := rows.Scan(&tag.Tag, &tag.RecentCount, &tag.BaselineCount, &tag.Growth); err != nil {
+			return nil, fmt.Errorf("failed to scan trending tag row: %v", err)
+		}
+		tags = append(tags, &tag)
+	}
+
+	return tags, rows.Err()
+}
+
+// AddTagAlias makes alias resolve to tag, replacing what it resolved to
+// before. Both are normalized the way story tags are, since aliases are
+// looked up by the normalized tag.
+func (c *ContentRepo) AddTagAlias(ctx context.Context, alias, tag string) error {
+	alias, tag = help.NormalizeTag(alias), help.NormalizeTag(tag)
+	if alias == "" || tag == "" || alias == tag {
+		return ErrInvalidTagAlias
+	}
+
+	query := `
+        INSERT INTO tag_aliases (alias, tag)
+        VALUES ($1, $2)
+        ON CONFLICT (alias) DO UPDATE SET tag = EXCLUDED.tag
+    `
+	if _, err := c.DB.ExecContext(ctx, query, alias, tag); err != nil {
+		return fmt.Errorf("failed to add tag alias: %v", err)
+	}
+	return nil
+}
+
+// normalizeTagAliases rewrites the existing aliases in normalized form.
+// Aliases that become blank or resolve to themselves are dropped, and when
+// two aliases collide the one already normalized wins.
+func (c *ContentRepo) normalizeTagAliases(ctx context.Context) error {
+	tx, err := c.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	rows, err := tx.QueryContext(ctx, `SELECT alias, tag FROM tag_aliases ORDER BY alias FOR UPDATE`)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to fetch tag aliases: %v", err)
+	}
+	type tagAlias struct{ alias, tag string }
+	var aliases []tagAlias
+	for rows.Next() {
+		var a tagAlias
+		if err := rows.Scan(&a.alias, &a.tag); err != nil {
+			rows.Close()
+			tx.Rollback()
+			return err
+		}
+		aliases = append(aliases, a)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	for _, a := range aliases {
+		alias, tag := help.NormalizeTag(a.alias), help.NormalizeTag(a.tag)
+		if alias == a.alias && tag == a.tag {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM tag_aliases WHERE alias = $1`, a.alias); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to normalize tag alias: %v", err)
+		}
+		if alias == "" || tag == "" || alias == tag {
+			continue
+		}
+		_, err := tx.ExecContext(ctx, `
+            INSERT INTO tag_aliases (alias, tag)
+            VALUES ($1, $2)
+            ON CONFLICT (alias) DO NOTHING
+        `, alias, tag)
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to normalize tag alias: %v", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// BackfillTags rewrites the existing tag aliases and story tags in
+// normalized form, resolving aliases, and reports how many distinct story
+// tags were rewritten. onRewrite, when not nil, is called with every
+// rewritten tag and the tag that replaced it, or an empty string when it
+// was dropped.
+func (c *ContentRepo) BackfillTags(ctx context.Context, onRewrite func(tag, canonical string)) (int64, error) {
+	if err := c.normalizeTagAliases(ctx); err != nil {
+		return 0, err
+	}
+
+	rows, err := c.DB.QueryContext(ctx, `SELECT DISTINCT tag FROM story_tags`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch tags: %v", err)
+	}
+	var tags []string
+	for rows.Next() {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		tags = append(tags, tag)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	var rewritten int64
+	for _, tag := range tags {
+		var canonical string
+		err := c.DB.QueryRowContext(ctx, `SELECT `+canonicalTagSQL("$1::TEXT"), help.NormalizeTag(tag)).Scan(&canonical)
+		if err != nil {
+			return rewritten, fmt.Errorf("failed to resolve tag: %v", err)
+		}
+		if canonical == tag {
+			continue
+		}
+
+		tx, err := c.DB.BeginTx(ctx, nil)
+		if err != nil {
+			return rewritten, err
+		}
+		if canonical != "" {
+			_, err = tx.ExecContext(ctx, `
+                INSERT INTO story_tags (story_id, tag)
+                SELECT story_id, $2 FROM story_tags WHERE tag = $1
+                ON CONFLICT DO NOTHING
+            `, tag, canonical)
+			if err != nil {
+				tx.Rollback()
+				return rewritten, fmt.Errorf("failed to rewrite tag: %v", err)
+			}
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM story_tags WHERE tag = $1`, tag); err != nil {
+			tx.Rollback()
+			return rewritten, fmt.Errorf("failed to rewrite tag: %v", err)
+		}
+		if err := tx.Commit(); err != nil {
+			return rewritten, err
+		}
+		if onRewrite != nil {
+			onRewrite(tag, canonical)
+		}
+		rewritten++
+	}
+
+	return rewritten, nil
+}
@@ -0,0 +1,57 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// tagAliasOf returns what alias resolves to, or "" when it is not an alias.
+func tagAliasOf(t *testing.T, c *ContentRepo, alias string) string {
+	t.Helper()
+	var tag string
+	err := c.DB.QueryRow(`SELECT coalesce((SELECT tag FROM tag_aliases WHERE alias = $1), '')`, alias).Scan(&tag)
+	if err != nil {
+		t.Fatalf("failed to look up tag alias: %v", err)
+	}
+	return tag
+}
+
+func TestAddTagAliasNormalizes(t *testing.T) {
+	c := newTestRepo(t)
+	suffix := createTestUser(t, c)[:8]
+	alias, tag := "nyc "+suffix, "new york "+suffix
+	t.Cleanup(func() { c.DB.Exec(`DELETE FROM tag_aliases WHERE alias = $1`, alias) })
+
+	if err := c.AddTagAlias(context.Background(), "#NYC  "+suffix, "New York "+suffix); err != nil {
+		t.Fatal(err)
+	}
+	if got := tagAliasOf(t, c, alias); got != tag {
+		t.Errorf("alias %q resolves to %q, want %q", alias, got, tag)
+	}
+
+	if err := c.AddTagAlias(context.Background(), "Paris", "#paris"); !errors.Is(err, ErrInvalidTagAlias) {
+		t.Errorf("AddTagAlias to itself error = %v, want ErrInvalidTagAlias", err)
+	}
+}
+
+func TestBackfillTagsNormalizesAliases(t *testing.T) {
+	c := newTestRepo(t)
+	suffix := createTestUser(t, c)[:8]
+	raw, alias, tag := "NYC "+suffix, "nyc "+suffix, "new york "+suffix
+	t.Cleanup(func() { c.DB.Exec(`DELETE FROM tag_aliases WHERE alias IN ($1, $2)`, raw, alias) })
+
+	if _, err := c.DB.Exec(`INSERT INTO tag_aliases (alias, tag) VALUES ($1, $2)`, raw, "New York "+suffix); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.BackfillTags(context.Background(), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := tagAliasOf(t, c, raw); got != "" {
+		t.Errorf("unnormalized alias %q still resolves to %q", raw, got)
+	}
+	if got := tagAliasOf(t, c, alias); got != tag {
+		t.Errorf("alias %q resolves to %q, want %q", alias, got, tag)
+	}
+}
@@ -0,0 +1,195 @@
+package postgres
+
+import (
+	pb "Content-Service/genproto/content"
+	"context"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// inTrashSQL matches rows of alias deleted less than $n seconds ago, i.e.
+// still inside the restore window.
+func inTrashSQL(alias string, n int) string {
+	return fmt.Sprintf("%[1]s.deleted_at > 0 AND %[1]s.deleted_at >= date_part('epoch', CURRENT_TIMESTAMP)::BIGINT - $%[2]d", alias, n)
+}
+
+// RestoreStory undeletes a story of userID deleted within the last
+// retentionSeconds and reports whether one was found.
+func (c *ContentRepo) RestoreStory(ctx context.Context, req *pb.RestoreStoryReq, retentionSeconds int64) (bool, error) {
+	return c.restore(ctx, "stories", req.StoryId, req.UserId, retentionSeconds)
+}
+
+// RestoreItinerary undeletes an itinerary of userID deleted within the last
+// retentionSeconds and reports whether one was found.
+func (c *ContentRepo) RestoreItinerary(ctx context.Context, req *pb.RestoreItineraryReq, retentionSeconds int64) (bool, error) {
+	return c.restore(ctx, "itineraries", req.ItineraryId, req.UserId, retentionSeconds)
+}
+
+func (c *ContentRepo) restore(ctx context.Context, table, id, userID string, retentionSeconds int64) (bool, error) {
+	query := `
+        UPDATE ` + table + ` t
+        SET deleted_at = 0, updated_at = CURRENT_TIMESTAMP
+        WHERE t.id = $1 AND t.author_id = $2 AND ` + inTrashSQL("t", 3)
+	result, err := c.DB.ExecContext(ctx, query, id, userID, retentionSeconds)
+	if err != nil {
+		return false, fmt.Errorf("failed to restore from %s: %v", table, err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return n > 0, nil
+}
+
+// ListTrash lists the stories and itineraries userID deleted within the last
+// retentionSeconds, most recently deleted first.
+func (c *ContentRepo) ListTrash(ctx context.Context, req *pb.ListTrashReq, retentionSeconds int64) (*pb.ListTrashRes, error) {
+	trash := `
+            SELECT 'story' AS item_type, s.id, s.title, s.deleted_at
+            FROM stories s
+            WHERE s.author_id = $1 AND ` + inTrashSQL("s", 2) + `
+            UNION ALL
+            SELECT 'itinerary', i.id, i.title, i.deleted_at
+            FROM itineraries i
+            WHERE i.author_id = $1 AND ` + inTrashSQL("i", 2)
+
+	var total int64
+	err := c.DB.QueryRowContext(ctx, `SELECT COUNT(*) FROM (`+trash+`) t`, req.UserId, retentionSeconds).Scan(&total)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count trash: %v", err)
+	}
+
+	query := `
+        SELECT item_type, id, title, to_timestamp(deleted_at)::TEXT, to_timestamp(deleted_at + $2)::TEXT
+        FROM (` + trash + `) t
+        ORDER BY deleted_at DESC
+        LIMIT $3 OFFSET $4
+    `
+	rows, err := c.DB.QueryContext(ctx, query, req.UserId, retentionSeconds, req.Limit, req.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch trash: %v", err)
+	}
+	defer rows.Close()
+
+	var items []*pb.TrashItem
+	for rows.Next() {
+		var item pb.TrashItem
+		if err := rows.Scan(&item.ItemType, &item.Id, &item.Title, &item.DeletedAt, &item.PurgeAt); err != nil {
+			return nil, fmt.Errorf("failed to scan trash row: %v", err)
+		}
+		items = append(items, &item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &pb.ListTrashRes{
+		Items:  items,
+		Total:  total,
+		Limit:  req.Limit,
+		Offset: req.Offset,
+	}, nil
+}
+
+// storyDependents delete the rows that belong to the stories whose ids are
+// bound to $1.
+var storyDependents = []string{
+	`DELETE FROM story_tags WHERE story_id = ANY($1::UUID[])`,
+	`DELETE FROM mentions WHERE story_id = ANY($1::UUID[])`,
+	`DELETE FROM likes WHERE story_id = ANY($1::UUID[])`,
+	`DELETE FROM comments WHERE story_id = ANY($1::UUID[])`,
+	`DELETE FROM story_revisions WHERE story_id = ANY($1::UUID[])`,
+	`DELETE FROM story_media WHERE story_id = ANY($1::UUID[])`,
+	`DELETE FROM share_links WHERE item_type = 'story' AND item_id = ANY($1::UUID[])`,
+	`DELETE FROM collection_items WHERE item_type = 'story' AND item_id = ANY($1::UUID[])`,
+	`DELETE FROM series_stories WHERE story_id = ANY($1::UUID[])`,
+	`DELETE FROM story_daily_views WHERE story_id = ANY($1::UUID[])`,
+}
+
+// itineraryDependents delete the rows that belong to the itineraries whose
+// ids are bound to $1.
+var itineraryDependents = []string{
+	`DELETE FROM itinerary_activities WHERE destination_id IN (
+        SELECT id FROM itinerary_destinations WHERE itinerary_id = ANY($1::UUID[]))`,
+	`DELETE FROM itinerary_destinations WHERE itinerary_id = ANY($1::UUID[])`,
+	`DELETE FROM comment WHERE itinerary_id = ANY($1::UUID[])`,
+	`DELETE FROM itinerary_collaborators WHERE itinerary_id = ANY($1::UUID[])`,
+	`DELETE FROM itinerary_media WHERE itinerary_id = ANY($1::UUID[])`,
+	`DELETE FROM share_links WHERE item_type = 'itinerary' AND item_id = ANY($1::UUID[])`,
+	`DELETE FROM collection_items WHERE item_type = 'itinerary' AND item_id = ANY($1::UUID[])`,
+}
+
+// PurgeDeletedStories permanently removes up to limit stories deleted more
+// than retentionSeconds ago, with everything that belongs to them, and
+// returns how many were removed.
+func (c *ContentRepo) PurgeDeletedStories(ctx context.Context, retentionSeconds int64, limit int) (int, error) {
+	return c.purge(ctx, "stories", storyDependents, retentionSeconds, limit)
+}
+
+// PurgeDeletedItineraries permanently removes up to limit itineraries
+// deleted more than retentionSeconds ago, with everything that belongs to
+// them, and returns how many were removed.
+func (c *ContentRepo) PurgeDeletedItineraries(ctx context.Context, retentionSeconds int64, limit int) (int, error) {
+	return c.purge(ctx, "itineraries", itineraryDependents, retentionSeconds, limit)
+}
+
+// purge claims expired rows of table with SKIP LOCKED, so concurrent purges
+// on other replicas work on different rows, and deletes them together with
+// their dependents in one transaction.
+func (c *ContentRepo) purge(ctx context.Context, table string, dependents []string, retentionSeconds int64, limit int) (int, error) {
+	tx, err := c.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	query := `
+        SELECT id
+        FROM ` + table + `
+        WHERE deleted_at > 0 AND deleted_at < date_part('epoch', CURRENT_TIMESTAMP)::BIGINT - $1
+        ORDER BY deleted_at
+        LIMIT $2
+        FOR UPDATE SKIP LOCKED
+    `
+	rows, err := tx.QueryContext(ctx, query, retentionSeconds, limit)
+	if err != nil {
+		tx.Rollback()
+		return 0, fmt.Errorf("failed to fetch expired %s: %v", table, err)
+	}
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			tx.Rollback()
+			return 0, fmt.Errorf("failed to scan expired %s row: %v", table, err)
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, tx.Rollback()
+	}
+
+	for _, statement := range dependents {
+		if _, err := tx.ExecContext(ctx, statement, pq.Array(ids)); err != nil {
+			tx.Rollback()
+			return 0, fmt.Errorf("failed to purge %s: %v", table, err)
+		}
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM `+table+` WHERE id = ANY($1::UUID[])`, pq.Array(ids)); err != nil {
+		tx.Rollback()
+		return 0, fmt.Errorf("failed to purge %s: %v", table, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return len(ids), nil
+}
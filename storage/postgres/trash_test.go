@@ -0,0 +1,217 @@
+package postgres
+
+import (
+	pb "Content-Service/genproto/content"
+	"context"
+	"testing"
+)
+
+const testRetentionSeconds = 24 * 60 * 60
+
+// trashTestItem moves a story or itinerary to the trash ago seconds ago.
+func trashTestItem(t *testing.T, c *ContentRepo, table, id string, ago int64) {
+	t.Helper()
+	query := `UPDATE ` + table + ` SET deleted_at = date_part('epoch', CURRENT_TIMESTAMP)::BIGINT - $2 WHERE id = $1`
+	if _, err := c.DB.Exec(query, id, ago); err != nil {
+		t.Fatalf("failed to trash test item: %v", err)
+	}
+}
+
+// countTestRows counts the rows of table whose column equals value.
+func countTestRows(t *testing.T, c *ContentRepo, table, column, value string) int {
+	t.Helper()
+	var n int
+	if err := c.DB.QueryRow(`SELECT COUNT(*) FROM `+table+` WHERE `+column+` = $1`, value).Scan(&n); err != nil {
+		t.Fatalf("failed to count %s: %v", table, err)
+	}
+	return n
+}
+
+func TestRestoreStory(t *testing.T) {
+	c := newTestRepo(t)
+	ctx := context.Background()
+	author, other := createTestUser(t, c), createTestUser(t, c)
+	recent := createTestStory(t, c, &pb.CreateStoriesRequest{UserId: author})
+	expired := createTestStory(t, c, &pb.CreateStoriesRequest{UserId: author})
+	trashTestItem(t, c, "stories", recent.Id, 60*60)
+	trashTestItem(t, c, "stories", expired.Id, 2*testRetentionSeconds)
+
+	tests := []struct {
+		name    string
+		storyID string
+		userID  string
+		want    bool
+	}{
+		{"not the author", recent.Id, other, false},
+		{"outside the window", expired.Id, author, false},
+		{"inside the window", recent.Id, author, true},
+	}
+	for _, tt := range tests {
+		got, err := c.RestoreStory(ctx, &pb.RestoreStoryReq{StoryId: tt.storyID, UserId: tt.userID}, testRetentionSeconds)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("%s: RestoreStory = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	var deletedAt int64
+	if err := c.DB.QueryRow(`SELECT deleted_at FROM stories WHERE id = $1`, recent.Id).Scan(&deletedAt); err != nil {
+		t.Fatal(err)
+	}
+	if deletedAt != 0 {
+		t.Errorf("restored story is still in the trash")
+	}
+}
+
+func TestListTrash(t *testing.T) {
+	c := newTestRepo(t)
+	author := createTestUser(t, c)
+	story := createTestStory(t, c, &pb.CreateStoriesRequest{UserId: author})
+	expired := createTestStory(t, c, &pb.CreateStoriesRequest{UserId: author})
+	itinerary := createTestItinerary(t, c, author)
+	trashTestItem(t, c, "stories", story.Id, 60*60)
+	trashTestItem(t, c, "itineraries", itinerary.Id, 2*60*60)
+	trashTestItem(t, c, "stories", expired.Id, 2*testRetentionSeconds)
+
+	trash, err := c.ListTrash(context.Background(), &pb.ListTrashReq{UserId: author, Limit: 10}, testRetentionSeconds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if trash.Total != 2 || len(trash.Items) != 2 {
+		t.Fatalf("trash has %d of %d items, want 2 of 2", len(trash.Items), trash.Total)
+	}
+
+	want := []struct{ itemType, id, table string }{
+		{ItemStory, story.Id, "stories"},
+		{ItemItinerary, itinerary.Id, "itineraries"},
+	}
+	for i, item := range trash.Items {
+		if item.ItemType != want[i].itemType || item.Id != want[i].id {
+			t.Errorf("item %d = %s %s, want %s %s", i, item.ItemType, item.Id, want[i].itemType, want[i].id)
+			continue
+		}
+		var purgeAt string
+		query := `SELECT to_timestamp(deleted_at + $2)::TEXT FROM ` + want[i].table + ` WHERE id = $1`
+		if err := c.DB.QueryRow(query, item.Id, testRetentionSeconds).Scan(&purgeAt); err != nil {
+			t.Fatal(err)
+		}
+		if item.PurgeAt != purgeAt {
+			t.Errorf("item %d purge_at = %q, want %q", i, item.PurgeAt, purgeAt)
+		}
+	}
+}
+
+func TestPurgeDeletedStories(t *testing.T) {
+	c := newTestRepo(t)
+	ctx := context.Background()
+	author := createTestUser(t, c)
+	expired := createTestStory(t, c, &pb.CreateStoriesRequest{UserId: author, Tags: []string{"purge"}})
+	recent := createTestStory(t, c, &pb.CreateStoriesRequest{UserId: author, Tags: []string{"purge"}})
+
+	if _, err := c.CommentToStory(ctx, &pb.CommentStoryReq{StoryId: expired.Id, Content: "Nice", AuthorId: author}); err != nil {
+		t.Fatal(err)
+	}
+	collection, err := c.CreateCollection(ctx, &pb.CreateCollectionReq{UserId: author, Name: "Trip ideas"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	series, err := c.CreateSeries(ctx, &pb.CreateSeriesReq{UserId: author, Title: "Test series"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{expired.Id, recent.Id} {
+		if _, err := c.AddToCollection(ctx, &pb.CollectionItemReq{UserId: author, CollectionId: collection.Id, ItemType: ItemStory, ItemId: id}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.AddStoryToSeries(ctx, &pb.AddStoryToSeriesReq{UserId: author, SeriesId: series.Id, StoryId: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	trashTestItem(t, c, "stories", expired.Id, 2*testRetentionSeconds)
+	trashTestItem(t, c, "stories", recent.Id, 60*60)
+
+	purgeAllTest(t, c.PurgeDeletedStories)
+
+	dependents := []struct{ table, column string }{
+		{"stories", "id"},
+		{"story_tags", "story_id"},
+		{"comments", "story_id"},
+		{"collection_items", "item_id"},
+		{"series_stories", "story_id"},
+	}
+	for _, d := range dependents {
+		if n := countTestRows(t, c, d.table, d.column, expired.Id); n != 0 {
+			t.Errorf("%d %s rows of the expired story left", n, d.table)
+		}
+		if d.table == "comments" {
+			continue
+		}
+		if n := countTestRows(t, c, d.table, d.column, recent.Id); n != 1 {
+			t.Errorf("%d %s rows of the story inside the window, want 1", n, d.table)
+		}
+	}
+}
+
+func TestPurgeDeletedItineraries(t *testing.T) {
+	c := newTestRepo(t)
+	ctx := context.Background()
+	author := createTestUser(t, c)
+	expired := createTestItinerary(t, c, author)
+	recent := createTestItinerary(t, c, author)
+
+	destinations := make(map[string]string)
+	for _, itinerary := range []*pb.ItinerariesRes{expired, recent} {
+		destination, err := c.AddItineraryDestination(ctx, &pb.AddItineraryDestinationReq{
+			ItineraryId: itinerary.Id,
+			UserId:      author,
+			Destination: &pb.Destination{Name: "Bukhara", StartDate: "2024-06-05", EndDate: "2024-06-10"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = c.AddItineraryActivity(ctx, &pb.AddItineraryActivityReq{
+			DestinationId: destination.Id, UserId: author, Activity: &pb.Activities{Text: "Old town walk"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		destinations[itinerary.Id] = destination.Id
+	}
+	trashTestItem(t, c, "itineraries", expired.Id, 2*testRetentionSeconds)
+	trashTestItem(t, c, "itineraries", recent.Id, 60*60)
+
+	purgeAllTest(t, c.PurgeDeletedItineraries)
+
+	if n := countTestRows(t, c, "itineraries", "id", expired.Id); n != 0 {
+		t.Errorf("expired itinerary was not purged")
+	}
+	if n := countTestRows(t, c, "itinerary_destinations", "itinerary_id", expired.Id); n != 0 {
+		t.Errorf("%d destinations of the expired itinerary left", n)
+	}
+	if n := countTestRows(t, c, "itinerary_activities", "destination_id", destinations[expired.Id]); n != 0 {
+		t.Errorf("%d activities of the expired itinerary left", n)
+	}
+	if n := countTestRows(t, c, "itineraries", "id", recent.Id); n != 1 {
+		t.Errorf("itinerary inside the window was purged")
+	}
+	if n := countTestRows(t, c, "itinerary_activities", "destination_id", destinations[recent.Id]); n != 1 {
+		t.Errorf("%d activities of the itinerary inside the window, want 1", n)
+	}
+}
+
+// purgeAllTest purges in batches until nothing expired is left, since the
+// database may hold expired items of other tests.
+func purgeAllTest(t *testing.T, purge func(context.Context, int64, int) (int, error)) {
+	t.Helper()
+	const limit = 100
+	for {
+		n, err := purge(context.Background(), testRetentionSeconds, limit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n < limit {
+			return
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package postgres
+
+import (
+	pb "Content-Service/genproto/content"
+	"context"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// TrendingParams configure trending scores. A story's points are the
+// weighted sum of its likes, comments and unique views, and its score is
+// points / (hours since publishing + 2) ^ Gravity, so higher gravity lets
+// stories fall faster. Only stories published in the last WindowHours
+// compete.
+type TrendingParams struct {
+	Gravity       float64
+	LikeWeight    float64
+	CommentWeight float64
+	ViewWeight    float64
+	WindowHours   int64
+}
+
+// pointsSQL returns the expression computing the points of the story alias
+// from its counters.
+func (p TrendingParams) pointsSQL(alias string) string {
+	return fmt.Sprintf("(%[1]s.likes_count * %[2]g + %[1]s.comments_count * %[3]g + %[1]s.views_count * %[4]g)",
+		alias, p.LikeWeight, p.CommentWeight, p.ViewWeight)
+}
+
+// trendingWindowSQL matches published, live stories of alias published in
+// the last $n hours.
+func trendingWindowSQL(alias string, n int) string {
+	return fmt.Sprintf(`%[1]s.deleted_at = 0 AND %[1]s.status = 'published'
+              AND %[1]s.published_at >= CURRENT_TIMESTAMP - make_interval(hours => $%[2]d::INT)`, alias, n)
+}
+
+// GetTrendingStories ranks the stories listed for the viewer by decayed
+// score. When ids is nil the points come from the story counters;
+// otherwise ids and points give the points of each candidate story and
+// stories missing from ids are left out.
+func (c *ContentRepo) GetTrendingStories(ctx context.Context, req *pb.GetTrendingStoriesReq, params TrendingParams, ids []string, points []float64) (*pb.GetTrendingStoriesRes, error) {
+	args := []interface{}{req.ViewerId, params.WindowHours}
+	candidates := `SELECT s.id AS story_id, ` + params.pointsSQL("s") + ` AS points FROM stories s`
+	if ids != nil {
+		candidates = `SELECT * FROM unnest($3::UUID[], $4::FLOAT8[]) AS p(story_id, points)`
+		args = append(args, pq.Array(ids), pq.Array(points))
+	}
+
+	ranked := fmt.Sprintf(`
+        WITH candidates AS (%s),
+        ranked AS (
+            SELECT s.id, p.points / power(date_part('epoch', CURRENT_TIMESTAMP - s.published_at) / 3600 + 2, %g) AS score
+            FROM candidates p
+            JOIN stories s ON s.id = p.story_id
+            WHERE %s AND %s
+        )`, candidates, params.Gravity, trendingWindowSQL("s", 2), listedStorySQL("s", viewerParam(1)))
+
+	res := &pb.GetTrendingStoriesRes{
+		Limit:  req.Limit,
+		Offset: req.Offset,
+	}
+	err := c.DB.QueryRowContext(ctx, ranked+` SELECT COUNT(*) FROM ranked`, args...).Scan(&res.Total)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count trending stories: %v", err)
+	}
+
+	query := ranked + fmt.Sprintf(`
+        SELECT `+storySummaryColumns+`
+        FROM ranked r
+        JOIN stories s ON s.id = r.id
+        JOIN users u ON s.author_id = u.id
+        ORDER BY r.score DESC, s.published_at DESC
+        LIMIT $%d OFFSET $%d
+    `, len(args)+1, len(args)+2)
+	rows, err := c.DB.QueryContext(ctx, query, append(args, req.Limit, req.Offset)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch trending stories: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		story, err := scanStorySummary(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan trending story row: %v", err)
+		}
+		res.Stories = append(res.Stories, story)
+	}
+
+	return res, rows.Err()
+}
+
+// TrendingPoints returns the points of every story inside the trending
+// window that has any, to seed the trending set.
+func (c *ContentRepo) TrendingPoints(ctx context.Context, params TrendingParams) ([]string, []float64, error) {
+	query := `
+        SELECT s.id, ` + params.pointsSQL("s") + `
+        FROM stories s
+        WHERE ` + trendingWindowSQL("s", 1) + ` AND ` + params.pointsSQL("s") + ` > 0
+    `
+	rows, err := c.DB.QueryContext(ctx, query, params.WindowHours)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch trending points: %v", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	var points []float64
+	for rows.Next() {
+		var id string
+		var p float64
+		if err := rows.Scan(&id, &p); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan trending points row: %v", err)
+		}
+		ids = append(ids, id)
+		points = append(points, p)
+	}
+
+	return ids, points, rows.Err()
+}
+
+// StaleTrendingStories returns the ids that no longer belong in the
+// trending set: stories that were deleted, are not published or have left
+// the trending window.
+func (c *ContentRepo) StaleTrendingStories(ctx context.Context, ids []string, windowHours int64) ([]string, error) {
+	query := `
+        SELECT c.story_id
+        FROM unnest($1::UUID[]) AS c(story_id)
+        WHERE NOT EXISTS (SELECT 1 FROM stories s WHERE s.id = c.story_id AND ` + trendingWindowSQL("s", 2) + `)
+    `
+	rows, err := c.DB.QueryContext(ctx, query, pq.Array(ids), windowHours)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find stale trending stories: %v", err)
+	}
+	defer rows.Close()
+
+	var stale []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan stale trending story row: %v", err)
+		}
+		stale = append(stale, id)
+	}
+
+	return stale, rows.Err()
+}
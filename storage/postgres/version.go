@@ -0,0 +1,29 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+)
+
+// VersionConflictError is returned by an update whose expected version no
+// longer matches the stored row because someone else changed it first.
+type VersionConflictError struct {
+	Expected int64
+	Current  int64
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("version conflict: expected version %d but the current version is %d", e.Expected, e.Current)
+}
+
+// versionConflict explains why a versioned update of a live row in table
+// matched nothing: it returns a *VersionConflictError when the row exists
+// with another version and sql.ErrNoRows when it does not exist.
+func versionConflict(ctx context.Context, q queryRower, table, id string, expected int64) error {
+	var current int64
+	err := q.QueryRowContext(ctx, `SELECT version FROM `+table+` WHERE id = $1 AND deleted_at = 0`, id).Scan(&current)
+	if err != nil {
+		return err
+	}
+	return &VersionConflictError{Expected: expected, Current: current}
+}
@@ -0,0 +1,63 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// StoryViews is the number of unique viewers a story had on one day, as
+// estimated by the view counter.
+type StoryViews struct {
+	StoryID string
+	Day     string
+	Views   int64
+}
+
+// FlushStoryViews stores daily unique viewer counts and refreshes the
+// views_count of the stories they belong to. A day's count only grows, so
+// flushing the same day again replaces the older estimate. Views of stories
+// that no longer exist are dropped.
+func (c *ContentRepo) FlushStoryViews(ctx context.Context, views []StoryViews) error {
+	if len(views) == 0 {
+		return nil
+	}
+
+	storyIDs := make([]string, len(views))
+	days := make([]string, len(views))
+	counts := make([]int64, len(views))
+	for i, v := range views {
+		storyIDs[i], days[i], counts[i] = v.StoryID, v.Day, v.Views
+	}
+
+	tx, err := c.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	query := `
+        INSERT INTO story_daily_views (story_id, day, views)
+        SELECT v.story_id, v.day, v.views
+        FROM unnest($1::UUID[], $2::DATE[], $3::BIGINT[]) AS v(story_id, day, views)
+        JOIN stories s ON s.id = v.story_id
+        ON CONFLICT (story_id, day) DO UPDATE
+        SET views = GREATEST(story_daily_views.views, EXCLUDED.views)
+    `
+	if _, err := tx.ExecContext(ctx, query, pq.Array(storyIDs), pq.Array(days), pq.Array(counts)); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to store story views: %v", err)
+	}
+
+	query = `
+        UPDATE stories s
+        SET views_count = (SELECT coalesce(SUM(views), 0) FROM story_daily_views WHERE story_id = s.id)
+        WHERE s.id = ANY($1::UUID[])
+    `
+	if _, err := tx.ExecContext(ctx, query, pq.Array(storyIDs)); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to update story views: %v", err)
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,171 @@
+package postgres
+
+import (
+	pb "Content-Service/genproto/content"
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+const (
+	VisibilityPrivate   = "private"
+	VisibilityFollowers = "followers"
+	VisibilityUnlisted  = "unlisted"
+	VisibilityPublic    = "public"
+)
+
+const (
+	ItemStory     = "story"
+	ItemItinerary = "itinerary"
+)
+
+// ValidVisibility reports whether v is one of the known visibility levels.
+func ValidVisibility(v string) bool {
+	switch v {
+	case VisibilityPrivate, VisibilityFollowers, VisibilityUnlisted, VisibilityPublic:
+		return true
+	}
+	return false
+}
+
+// listedSQL returns a condition selecting the rows of alias that appear in
+// listings for the viewer given by the SQL expression viewer: public rows,
+// the viewer's own rows and followers-only rows of authors they follow.
+// Unlisted rows are only reachable directly.
+func listedSQL(alias, viewer string) string {
+	return fmt.Sprintf(`(%[1]s.visibility = 'public' OR %[1]s.author_id = %[2]s OR (%[1]s.visibility = 'followers' AND EXISTS (
+            SELECT 1 FROM followers f WHERE f.follower_id = %[2]s AND f.following_id = %[1]s.author_id)))`, alias, viewer)
+}
+
+// viewerParam returns the SQL expression for an optional viewer id bound to
+// parameter n.
+func viewerParam(n int) string {
+	return fmt.Sprintf("NULLIF($%d, '')::UUID", n)
+}
+
+func itemTable(itemType string) (string, error) {
+	switch itemType {
+	case ItemStory:
+		return "stories", nil
+	case ItemItinerary:
+		return "itineraries", nil
+	}
+	return "", fmt.Errorf("unknown item type %q", itemType)
+}
+
+// ItemOwner returns the author and visibility of a live story or itinerary.
+func (c *ContentRepo) ItemOwner(ctx context.Context, itemType, id string) (string, string, error) {
+	table, err := itemTable(itemType)
+	if err != nil {
+		return "", "", err
+	}
+
+	var authorID, visibility string
+	query := `SELECT author_id, visibility FROM ` + table + ` WHERE id = $1 AND deleted_at = 0`
+	err = c.DB.QueryRowContext(ctx, query, id).Scan(&authorID, &visibility)
+	if err != nil {
+		return "", "", err
+	}
+
+	return authorID, visibility, nil
+}
+
+// CanView reports whether viewerID may read a live story or itinerary. The
+// author can always read their content; others can read public content,
+// followers-only content of authors they follow and, when shared is true
+// because a valid share link was presented, unlisted content. Accepted
+// collaborators can read an itinerary whatever its visibility, and only the
+// author can read a story that is not published yet.
+func (c *ContentRepo) CanView(ctx context.Context, itemType, id, viewerID string, shared bool) (bool, error) {
+	table, err := itemTable(itemType)
+	if err != nil {
+		return false, err
+	}
+
+	listed := listedSQL("t", viewerParam(2))
+	if itemType == ItemItinerary {
+		listed = listedItinerarySQL("t", viewerParam(2))
+	}
+	condition := listed + ` OR (t.visibility = 'unlisted' AND $3)`
+	if itemType == ItemStory {
+		condition = "(" + condition + ") AND (t.status = 'published' OR t.author_id = " + viewerParam(2) + ")"
+	}
+
+	// Without a viewer the author and follower checks are NULL, so a NULL
+	// result means the item is hidden.
+	query := `
+        SELECT coalesce((` + condition + `), false)
+        FROM ` + table + ` t
+        WHERE t.id = $1 AND t.deleted_at = 0
+    `
+	var visible bool
+	err = c.DB.QueryRowContext(ctx, query, id, viewerID, shared).Scan(&visible)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to check visibility: %v", err)
+	}
+
+	return visible, nil
+}
+
+// CreateShareLink stores a new share link and returns it without a token;
+// the caller signs the token from the returned fields.
+func (c *ContentRepo) CreateShareLink(ctx context.Context, req *pb.CreateShareLinkReq) (*pb.ShareLink, int64, error) {
+	query := `
+        INSERT INTO share_links (item_type, item_id, created_by, expires_at)
+        VALUES ($1, $2, $3, CASE WHEN $4::BIGINT > 0 THEN CURRENT_TIMESTAMP + make_interval(secs => $4::BIGINT) END)
+        RETURNING id, item_type, item_id, coalesce(expires_at::TEXT, ''),
+                  coalesce(date_part('epoch', expires_at)::BIGINT, 0), created_at
+    `
+	var link pb.ShareLink
+	var expiresAt int64
+	err := c.DB.QueryRowContext(ctx, query, req.ItemType, req.ItemId, req.UserId, req.ExpiresInSeconds).Scan(
+		&link.Id, &link.ItemType, &link.ItemId, &link.ExpiresAt, &expiresAt, &link.CreatedAt)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create share link: %v", err)
+	}
+
+	return &link, expiresAt, nil
+}
+
+// GetActiveShareLink returns the item a link points to and its expiry as a
+// Unix timestamp, or sql.ErrNoRows when the link is unknown, revoked or
+// expired.
+func (c *ContentRepo) GetActiveShareLink(ctx context.Context, linkID string) (string, string, int64, error) {
+	query := `
+        SELECT item_type, item_id, coalesce(date_part('epoch', expires_at)::BIGINT, 0)
+        FROM share_links
+        WHERE id = $1 AND revoked_at IS NULL
+          AND (expires_at IS NULL OR expires_at > CURRENT_TIMESTAMP)
+    `
+	var itemType, itemID string
+	var expiresAt int64
+	err := c.DB.QueryRowContext(ctx, query, linkID).Scan(&itemType, &itemID, &expiresAt)
+	if err != nil {
+		return "", "", 0, err
+	}
+
+	return itemType, itemID, expiresAt, nil
+}
+
+// RevokeShareLink revokes a link created by userID and reports whether one
+// was found.
+func (c *ContentRepo) RevokeShareLink(ctx context.Context, req *pb.RevokeShareLinkReq) (bool, error) {
+	query := `
+        UPDATE share_links
+        SET revoked_at = CURRENT_TIMESTAMP
+        WHERE id = $1 AND created_by = $2 AND revoked_at IS NULL
+    `
+	result, err := c.DB.ExecContext(ctx, query, req.LinkId, req.UserId)
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke share link: %v", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return n > 0, nil
+}
@@ -0,0 +1,21 @@
+package redis
+
+import (
+	"context"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// newTestClient returns a client on an emptied scratch database of the
+// local Redis, skipping the test when it is unavailable.
+func newTestClient(t *testing.T) *redis.Client {
+	t.Helper()
+	rdb := redis.NewClient(&redis.Options{Addr: "localhost:6379", DB: 15})
+	if err := rdb.FlushDB(context.Background()).Err(); err != nil {
+		rdb.Close()
+		t.Skipf("redis unavailable: %v", err)
+	}
+	t.Cleanup(func() { rdb.Close() })
+	return rdb
+}
@@ -0,0 +1,101 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	relatedKeyPrefix    = "related:"
+	relatedTagKeyPrefix = "related:tag:"
+)
+
+// RelatedCache keeps the ranked related stories of each story. Alongside
+// every cached list it indexes the story under its tags, so a change to the
+// tags of one story can drop the lists of the stories that share them.
+type RelatedCache struct {
+	rdb *redis.Client
+	ttl time.Duration
+}
+
+// NewRelatedCache keeps cached lists for ttl.
+func NewRelatedCache(rdb *redis.Client, ttl time.Duration) *RelatedCache {
+	return &RelatedCache{rdb: rdb, ttl: ttl}
+}
+
+// Get returns the cached related stories of a story and whether there were
+// any cached. An empty list is a valid cached result.
+func (r *RelatedCache) Get(ctx context.Context, storyID string) ([]string, bool, error) {
+	value, err := r.rdb.Get(ctx, relatedKeyPrefix+storyID).Result()
+	if err == redis.Nil {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read related stories: %v", err)
+	}
+	return splitIDs(value), true, nil
+}
+
+// Set caches the related stories of a story that has the given tags.
+func (r *RelatedCache) Set(ctx context.Context, storyID string, tags, ids []string) error {
+	_, err := r.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, relatedKeyPrefix+storyID, strings.Join(ids, ","), r.ttl)
+		for _, tag := range tags {
+			key := relatedTagKeyPrefix + tag
+			pipe.SAdd(ctx, key, storyID)
+			pipe.Expire(ctx, key, r.ttl)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to cache related stories: %v", err)
+	}
+	return nil
+}
+
+// Invalidate drops the cached lists of a story and of every story cached
+// under one of the given tags.
+func (r *RelatedCache) Invalidate(ctx context.Context, storyID string, tags []string) error {
+	return r.invalidate(ctx, []string{relatedKeyPrefix + storyID}, tags)
+}
+
+// InvalidateTags drops the cached lists of every story cached under one of
+// the given tags, for changes that touch a tag rather than a story.
+func (r *RelatedCache) InvalidateTags(ctx context.Context, tags []string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+	return r.invalidate(ctx, nil, tags)
+}
+
+func (r *RelatedCache) invalidate(ctx context.Context, keys, tags []string) error {
+	if len(tags) > 0 {
+		tagKeys := make([]string, len(tags))
+		for i, tag := range tags {
+			tagKeys[i] = relatedTagKeyPrefix + tag
+		}
+		ids, err := r.rdb.SUnion(ctx, tagKeys...).Result()
+		if err != nil {
+			return fmt.Errorf("failed to find related stories to invalidate: %v", err)
+		}
+		for _, id := range ids {
+			keys = append(keys, relatedKeyPrefix+id)
+		}
+		keys = append(keys, tagKeys...)
+	}
+	if err := r.rdb.Del(ctx, keys...).Err(); err != nil {
+		return fmt.Errorf("failed to invalidate related stories: %v", err)
+	}
+	return nil
+}
+
+func splitIDs(value string) []string {
+	if value == "" {
+		return []string{}
+	}
+	return strings.Split(value, ",")
+}
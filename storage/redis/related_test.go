@@ -0,0 +1,69 @@
+package redis
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSplitIDs(t *testing.T) {
+	tests := []struct {
+		name string
+		ids  []string
+	}{
+		{"empty", []string{}},
+		{"one", []string{"a"}},
+		{"several", []string{"a", "b", "c"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := splitIDs(strings.Join(tt.ids, ","))
+			if !reflect.DeepEqual(got, tt.ids) {
+				t.Errorf("splitIDs() = %v, want %v", got, tt.ids)
+			}
+		})
+	}
+}
+
+func TestRelatedCacheInvalidate(t *testing.T) {
+	cache := NewRelatedCache(newTestClient(t), time.Minute)
+	ctx := context.Background()
+	for story, tags := range map[string][]string{
+		"hiking":  {"mountains", "hiking"},
+		"beaches": {"sea"},
+		"alps":    {"mountains"},
+	} {
+		if err := cache.Set(ctx, story, tags, []string{"x", "y"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cached := func(story string) bool {
+		t.Helper()
+		_, hit, err := cache.Get(ctx, story)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return hit
+	}
+
+	// A new story tagged mountains changes the rankings of the stories
+	// cached under that tag only.
+	if err := cache.Invalidate(ctx, "new", []string{"mountains"}); err != nil {
+		t.Fatal(err)
+	}
+	if cached("hiking") || cached("alps") {
+		t.Error("rankings under the changed tag are still cached")
+	}
+	if !cached("beaches") {
+		t.Error("ranking under another tag was dropped")
+	}
+
+	if err := cache.InvalidateTags(ctx, []string{"sea"}); err != nil {
+		t.Fatal(err)
+	}
+	if cached("beaches") {
+		t.Error("ranking under a rewritten tag is still cached")
+	}
+}
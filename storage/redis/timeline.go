@@ -0,0 +1,123 @@
+package redis
+
+import (
+	"Content-Service/storage/postgres"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const timelineKeyPrefix = "timeline:"
+
+// Timelines caches, per user, the feed items of the authors they follow
+// that have many followers, so the feed query can leave them out. Items are
+// written to every follower's timeline when they are published (fan-out on
+// write) and scored by their feed time in microseconds.
+type Timelines struct {
+	rdb  *redis.Client
+	size int64
+}
+
+// NewTimelines keeps the newest size items of each timeline.
+func NewTimelines(rdb *redis.Client, size int64) *Timelines {
+	return &Timelines{rdb: rdb, size: size}
+}
+
+// Add writes an item to the timelines of followers.
+func (t *Timelines) Add(ctx context.Context, followers []string, ref postgres.FeedRef) error {
+	member := redis.Z{Score: float64(ref.At.UnixMicro()), Member: ref.ItemType + ":" + ref.ItemID}
+	_, err := t.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, follower := range followers {
+			key := timelineKeyPrefix + follower
+			pipe.ZAdd(ctx, key, member)
+			pipe.ZRemRangeByRank(ctx, key, 0, -t.size-1)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to fan out feed item: %v", err)
+	}
+	return nil
+}
+
+// Before returns up to n items of a user's timeline at or before the given
+// time, newest first. A zero time starts from the newest item.
+func (t *Timelines) Before(ctx context.Context, userID string, before time.Time, n int64) ([]postgres.FeedRef, error) {
+	upper := "+inf"
+	if !before.IsZero() {
+		upper = strconv.FormatInt(before.UnixMicro(), 10)
+	}
+	members, err := t.rdb.ZRevRangeByScoreWithScores(ctx, timelineKeyPrefix+userID, &redis.ZRangeBy{
+		Min:   "-inf",
+		Max:   upper,
+		Count: n,
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read timeline: %v", err)
+	}
+
+	refs := make([]postgres.FeedRef, 0, len(members))
+	for _, m := range members {
+		itemType, itemID, ok := strings.Cut(m.Member.(string), ":")
+		if !ok {
+			continue
+		}
+		refs = append(refs, postgres.FeedRef{
+			ItemType: itemType,
+			ItemID:   itemID,
+			At:       time.UnixMicro(int64(m.Score)).UTC(),
+		})
+	}
+	return refs, nil
+}
+
+const fanOutQueueKey = "fanout:queue"
+
+// FanOutQueue holds new feed items waiting to be written to timelines, so
+// the request that created them does not wait for every follower.
+type FanOutQueue struct {
+	rdb *redis.Client
+}
+
+func NewFanOutQueue(rdb *redis.Client) *FanOutQueue {
+	return &FanOutQueue{rdb: rdb}
+}
+
+// Push queues items of one type.
+func (q *FanOutQueue) Push(ctx context.Context, itemType string, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	members := make([]interface{}, len(ids))
+	for i, id := range ids {
+		members[i] = itemType + ":" + id
+	}
+	if err := q.rdb.RPush(ctx, fanOutQueueKey, members...).Err(); err != nil {
+		return fmt.Errorf("failed to queue fan-out: %v", err)
+	}
+	return nil
+}
+
+// Pop removes and returns up to n queued items, oldest first, with only
+// their type and id set.
+func (q *FanOutQueue) Pop(ctx context.Context, n int) ([]postgres.FeedRef, error) {
+	members, err := q.rdb.LPopCount(ctx, fanOutQueueKey, n).Result()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fan-out queue: %v", err)
+	}
+
+	refs := make([]postgres.FeedRef, 0, len(members))
+	for _, m := range members {
+		if itemType, itemID, ok := strings.Cut(m, ":"); ok {
+			refs = append(refs, postgres.FeedRef{ItemType: itemType, ItemID: itemID})
+		}
+	}
+	return refs, nil
+}
@@ -0,0 +1,93 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	trendingKey       = "trending:stories"
+	trendingSeededKey = "trending:seeded"
+)
+
+// Trending keeps the engagement points of recently active stories in a
+// sorted set, incremented as likes, comments and views happen. Decay is
+// applied when ranking, since it depends on the time of the request.
+type Trending struct {
+	rdb *redis.Client
+}
+
+func NewTrending(rdb *redis.Client) *Trending {
+	return &Trending{rdb: rdb}
+}
+
+// Add adds points to a story.
+func (t *Trending) Add(ctx context.Context, storyID string, points float64) error {
+	if err := t.rdb.ZIncrBy(ctx, trendingKey, points, storyID).Err(); err != nil {
+		return fmt.Errorf("failed to update trending points: %v", err)
+	}
+	return nil
+}
+
+// Points returns every story in the set with its points.
+func (t *Trending) Points(ctx context.Context) ([]string, []float64, error) {
+	members, err := t.rdb.ZRangeWithScores(ctx, trendingKey, 0, -1).Result()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read trending points: %v", err)
+	}
+
+	ids := make([]string, len(members))
+	points := make([]float64, len(members))
+	for i, m := range members {
+		ids[i], points[i] = m.Member.(string), m.Score
+	}
+	return ids, points, nil
+}
+
+// Seed fills the set with points computed elsewhere. Stories already in it
+// keep their points when those are higher.
+func (t *Trending) Seed(ctx context.Context, ids []string, points []float64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	members := make([]redis.Z, len(ids))
+	for i, id := range ids {
+		members[i] = redis.Z{Score: points[i], Member: id}
+	}
+	if err := t.rdb.ZAddGT(ctx, trendingKey, members...).Err(); err != nil {
+		return fmt.Errorf("failed to seed trending points: %v", err)
+	}
+	return nil
+}
+
+// Remove removes stories from the set.
+func (t *Trending) Remove(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	if err := t.rdb.ZRem(ctx, trendingKey, toInterfaces(ids)...).Err(); err != nil {
+		return fmt.Errorf("failed to remove trending stories: %v", err)
+	}
+	return nil
+}
+
+// ClaimSeed reports whether the caller should seed the set. It returns true
+// once after the set was first used or lost with the rest of Redis, until
+// ReleaseSeed is called.
+func (t *Trending) ClaimSeed(ctx context.Context) (bool, error) {
+	claimed, err := t.rdb.SetNX(ctx, trendingSeededKey, 1, 0).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to claim trending seed: %v", err)
+	}
+	return claimed, nil
+}
+
+// ReleaseSeed lets the next ClaimSeed succeed again, after seeding failed.
+func (t *Trending) ReleaseSeed(ctx context.Context) error {
+	if err := t.rdb.Del(ctx, trendingSeededKey).Err(); err != nil {
+		return fmt.Errorf("failed to release trending seed: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package redis
+
+import (
+	"context"
+	"testing"
+)
+
+func TestTrendingSeedAfterEngagement(t *testing.T) {
+	trending := NewTrending(newTestClient(t))
+	ctx := context.Background()
+
+	// A like lands before anyone reads the set.
+	if err := trending.Add(ctx, "liked", 1); err != nil {
+		t.Fatal(err)
+	}
+	claimed, err := trending.ClaimSeed(ctx)
+	if err != nil || !claimed {
+		t.Fatalf("first ClaimSeed = %v, %v, want true", claimed, err)
+	}
+	if err := trending.Seed(ctx, []string{"liked", "old"}, []float64{10, 5}); err != nil {
+		t.Fatal(err)
+	}
+	if claimed, err := trending.ClaimSeed(ctx); err != nil || claimed {
+		t.Errorf("second ClaimSeed = %v, %v, want false", claimed, err)
+	}
+
+	ids, points, err := trending.Points(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]float64{}
+	for i, id := range ids {
+		got[id] = points[i]
+	}
+	if got["liked"] != 10 || got["old"] != 5 {
+		t.Errorf("points after seeding = %v, want liked 10 and old 5", got)
+	}
+}
@@ -0,0 +1,117 @@
+package redis
+
+import (
+	"Content-Service/storage/postgres"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	viewsKeyPrefix = "views:story:"
+	viewsDirtyKey  = "views:dirty"
+	viewsDayLayout = "2006-01-02"
+
+	// viewsKeyTTL keeps a day's estimate around long enough for a flusher
+	// that fell behind to still read it.
+	viewsKeyTTL = 48 * time.Hour
+)
+
+// ViewCounter estimates the unique viewers of each story per day with one
+// HyperLogLog per story and UTC day, so a viewer is counted at most once a
+// day however often they open a story. Days with new views are tracked in
+// a set until they are flushed to Postgres.
+type ViewCounter struct {
+	rdb *redis.Client
+}
+
+func NewViewCounter(rdb *redis.Client) *ViewCounter {
+	return &ViewCounter{rdb: rdb}
+}
+
+// Record counts a view of storyID by viewerID at now and reports whether it
+// was the viewer's first that day. Like the count, the answer is an
+// estimate.
+func (v *ViewCounter) Record(ctx context.Context, storyID, viewerID string, now time.Time) (bool, error) {
+	day := now.UTC().Format(viewsDayLayout)
+	key := viewsKey(storyID, day)
+	var added *redis.IntCmd
+	_, err := v.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		added = pipe.PFAdd(ctx, key, viewerID)
+		pipe.Expire(ctx, key, viewsKeyTTL)
+		pipe.SAdd(ctx, viewsDirtyKey, dirtyMember(storyID, day))
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to record view: %v", err)
+	}
+	return added.Val() == 1, nil
+}
+
+// Pop removes up to n story days with new views from the dirty set and
+// returns their current estimates. Callers that fail to store them should
+// Requeue them.
+func (v *ViewCounter) Pop(ctx context.Context, n int) ([]postgres.StoryViews, error) {
+	members, err := v.rdb.SPopN(ctx, viewsDirtyKey, int64(n)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to pop viewed stories: %v", err)
+	}
+
+	views := make([]postgres.StoryViews, 0, len(members))
+	for _, member := range members {
+		storyID, day, ok := parseDirtyMember(member)
+		if !ok {
+			continue
+		}
+		count, err := v.rdb.PFCount(ctx, viewsKey(storyID, day)).Result()
+		if err != nil {
+			v.rdb.SAdd(ctx, viewsDirtyKey, toInterfaces(members)...)
+			return nil, fmt.Errorf("failed to count story views: %v", err)
+		}
+		views = append(views, postgres.StoryViews{StoryID: storyID, Day: day, Views: count})
+	}
+
+	return views, nil
+}
+
+// Requeue marks story days as having unflushed views again.
+func (v *ViewCounter) Requeue(ctx context.Context, views []postgres.StoryViews) error {
+	if len(views) == 0 {
+		return nil
+	}
+	members := make([]string, len(views))
+	for i, view := range views {
+		members[i] = dirtyMember(view.StoryID, view.Day)
+	}
+	return v.rdb.SAdd(ctx, viewsDirtyKey, toInterfaces(members)...).Err()
+}
+
+func toInterfaces(members []string) []interface{} {
+	out := make([]interface{}, len(members))
+	for i, member := range members {
+		out[i] = member
+	}
+	return out
+}
+
+func viewsKey(storyID, day string) string {
+	return viewsKeyPrefix + storyID + ":" + day
+}
+
+func dirtyMember(storyID, day string) string {
+	return storyID + ":" + day
+}
+
+func parseDirtyMember(member string) (storyID, day string, ok bool) {
+	i := strings.LastIndexByte(member, ':')
+	if i <= 0 {
+		return "", "", false
+	}
+	if _, err := time.Parse(viewsDayLayout, member[i+1:]); err != nil {
+		return "", "", false
+	}
+	return member[:i], member[i+1:], true
+}
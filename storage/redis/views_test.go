@@ -0,0 +1,16 @@
+package redis
+
+import "testing"
+
+func TestParseDirtyMember(t *testing.T) {
+	storyID, day, ok := parseDirtyMember(dirtyMember("4b1c7e0a-95d2-4a8e-9f1d-2a6f0c3b8e11", "2024-05-06"))
+	if !ok || storyID != "4b1c7e0a-95d2-4a8e-9f1d-2a6f0c3b8e11" || day != "2024-05-06" {
+		t.Errorf("parseDirtyMember round trip = %q, %q, %v", storyID, day, ok)
+	}
+
+	for _, member := range []string{"", "no-day", ":2024-05-06", "story:yesterday"} {
+		if _, _, ok := parseDirtyMember(member); ok {
+			t.Errorf("parseDirtyMember(%q) succeeded, want failure", member)
+		}
+	}
+}